@@ -0,0 +1,137 @@
+// Command drainplan previews which pods each timed drain strategy would act on, and when, for a worker
+// node or the whole worker pool, using the operator's current node drain configuration and UpgradeConfig.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	muocfg "github.com/openshift/managed-upgrade-operator/config"
+	"github.com/openshift/managed-upgrade-operator/pkg/apis"
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/configmanager"
+	"github.com/openshift/managed-upgrade-operator/pkg/drain"
+	"github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager"
+)
+
+// drainPlanConfig holds the node drain section of the operator's config
+type drainPlanConfig struct {
+	NodeDrain drain.NodeDrain `yaml:"nodeDrain"`
+}
+
+func (cfg *drainPlanConfig) IsValid() error {
+	return cfg.NodeDrain.IsValid()
+}
+
+func main() {
+	namespace := flag.String("namespace", muocfg.OperatorNamespace, "namespace the operator is deployed in")
+	nodeName := flag.String("node", "", "node to preview the drain plan of, defaults to every worker node")
+	output := flag.String("o", "text", "output format, text or json")
+	flag.Parse()
+
+	plans, err := getDrainPlans(*namespace, *nodeName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to preview drain plan: %v\n", err)
+		os.Exit(1)
+	}
+
+	switch *output {
+	case "json":
+		err = json.NewEncoder(os.Stdout).Encode(plans)
+	case "text":
+		err = printDrainPlans(plans)
+	default:
+		err = fmt.Errorf("output format %s is not supported", *output)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to print drain plan: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func getDrainPlans(namespace string, nodeName string) ([]*drain.NodeDrainPlan, error) {
+	kubeConfig, err := config.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := apis.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	c, err := client.New(kubeConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+
+	uc := &upgradev1alpha1.UpgradeConfig{}
+	err = c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: upgradeconfigmanager.UPGRADECONFIG_CR_NAME}, uc)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &drainPlanConfig{}
+	err = configmanager.NewBuilder().New(c, namespace).Into(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+	nds, err := drain.NewBuilder(kubeClient).NewNodeDrainStrategy(c, uc, &cfg.NodeDrain)
+	if err != nil {
+		return nil, err
+	}
+
+	if nodeName == "" {
+		return drain.GetWorkerDrainPlans(c, nds)
+	}
+	node := &corev1.Node{}
+	err = c.Get(context.TODO(), types.NamespacedName{Name: nodeName}, node)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := nds.GetPlan(node)
+	if err != nil {
+		return nil, err
+	}
+	return []*drain.NodeDrainPlan{plan}, nil
+}
+
+func printDrainPlans(plans []*drain.NodeDrainPlan) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NODE\tSTRATEGY\tAFTER\tAT\tPODS")
+	for _, plan := range plans {
+		for _, step := range plan.Steps {
+			at := "-"
+			if step.ExecuteAt != nil {
+				at = step.ExecuteAt.UTC().Format(time.RFC3339)
+			}
+			pods := "-"
+			if len(step.Pods) > 0 {
+				pods = strings.Join(step.Pods, ",")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", plan.NodeName, step.Name, step.WaitDuration.Duration, at, pods)
+		}
+	}
+	return w.Flush()
+}
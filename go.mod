@@ -25,6 +25,7 @@ require (
 	github.com/prometheus/alertmanager v0.20.0
 	github.com/prometheus/client_golang v1.7.1
 	github.com/prometheus/common v0.10.0
+	github.com/robfig/cron/v3 v3.0.1
 	github.com/sykesm/zap-logfmt v0.0.4
 	go.uber.org/zap v1.15.0
 	gopkg.in/yaml.v2 v2.3.0
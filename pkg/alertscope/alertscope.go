@@ -0,0 +1,243 @@
+// Package alertscope defines which alerts the operator considers when checking cluster health
+// and silencing alerts during maintenance, so that both remain consistent.
+package alertscope
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// defaultHealthCheckNamespaces are the namespaces whose alerts fail a health check if none are configured
+	defaultHealthCheckNamespaces = []string{"^openshift.*", "^kube-.*", "^default$"}
+	// defaultSilenceNamespaces are the namespaces whose alerts are silenced during maintenance if none are configured.
+	// They intentionally go beyond the health check defaults, as upgrades can raise warnings in these namespaces too.
+	defaultSilenceNamespaces = []string{"^openshift.*", "^kube.*", "^redhat.*", "^default$"}
+	// defaultCriticalSeverities are the alert severities which fail a health check if none are configured
+	defaultCriticalSeverities = []string{"critical"}
+	// defaultSilencedSeverities are the alert severities which are silenced during maintenance if none are configured
+	defaultSilencedSeverities = []string{"warning", "info"}
+)
+
+// AlertScope holds the configuration of which alerts are in scope for the operator
+type AlertScope struct {
+	// Regular expressions matching the namespaces whose alerts are in scope
+	Namespaces []string `yaml:"namespaces"`
+	// Names, or name prefixes followed by .*, of namespaces whose alerts are excluded from the health check and the silences
+	ExcludedNamespaces []string `yaml:"excludedNamespaces"`
+	// Severities of alerts which fail a health check
+	CriticalSeverities []string `yaml:"criticalSeverities"`
+	// Severities of alerts which are silenced during maintenance
+	SilencedSeverities []string `yaml:"silencedSeverities"`
+	// Additional label names and the regular expressions their values must match
+	LabelMatchers map[string]string `yaml:"labelMatchers"`
+}
+
+// GetHealthCheckNamespaces returns the namespace patterns in scope of a health check
+func (s *AlertScope) GetHealthCheckNamespaces() []string {
+	if len(s.Namespaces) == 0 {
+		return defaultHealthCheckNamespaces
+	}
+	return s.Namespaces
+}
+
+// GetSilenceNamespaces returns the namespace patterns in scope of the maintenance silences
+func (s *AlertScope) GetSilenceNamespaces() []string {
+	if len(s.Namespaces) == 0 {
+		return defaultSilenceNamespaces
+	}
+	return s.Namespaces
+}
+
+// GetCriticalSeverities returns the severities of alerts which fail a health check
+func (s *AlertScope) GetCriticalSeverities() []string {
+	if len(s.CriticalSeverities) == 0 {
+		return defaultCriticalSeverities
+	}
+	return s.CriticalSeverities
+}
+
+// GetSilencedSeverities returns the severities of alerts which are silenced during maintenance
+func (s *AlertScope) GetSilencedSeverities() []string {
+	if len(s.SilencedSeverities) == 0 {
+		return defaultSilencedSeverities
+	}
+	return s.SilencedSeverities
+}
+
+// HealthCheckNamespaceRegex returns a single regular expression matching the namespaces in scope of a health check
+func (s *AlertScope) HealthCheckNamespaceRegex() string {
+	return "(" + strings.Join(s.GetHealthCheckNamespaces(), "|") + ")"
+}
+
+// SilenceNamespaceRegex returns a single regular expression matching the namespaces in scope of the maintenance silences
+func (s *AlertScope) SilenceNamespaceRegex() string {
+	return "(" + strings.Join(s.GetSilenceNamespaces(), "|") + ")"
+}
+
+// SilenceIncludedNamespaceRegex returns a regular expression matching every namespace which is not excluded from
+// the scope, and whether any are. Alertmanager matchers cannot be negated, so the silences match this in addition
+// to the namespaces in scope, which keeps namespaces created during maintenance silenced unless they are excluded.
+func (s *AlertScope) SilenceIncludedNamespaceRegex() (string, bool) {
+	if len(s.ExcludedNamespaces) == 0 {
+		return "", false
+	}
+	root := &namespaceTrie{}
+	for _, pattern := range s.ExcludedNamespaces {
+		// Exclusions are checked by IsValid
+		excluded, _ := parseExcludedNamespace(pattern)
+		root.add(excluded)
+	}
+	return "(" + root.complement() + ")", true
+}
+
+// WithExcludedNamespaces returns a copy of the scope which also excludes the named namespaces
+func (s AlertScope) WithExcludedNamespaces(names ...string) AlertScope {
+	excluded := append([]string{}, s.ExcludedNamespaces...)
+	for _, name := range names {
+		excluded = append(excluded, "^"+regexp.QuoteMeta(name)+"$")
+	}
+	s.ExcludedNamespaces = excluded
+	return s
+}
+
+// LabelNames returns the names of the additional label matchers in a stable order
+func (s *AlertScope) LabelNames() []string {
+	names := make([]string, 0, len(s.LabelMatchers))
+	for name := range s.LabelMatchers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CriticalAlertSelectors returns the PromQL label selectors matching critical alerts in scope
+func (s *AlertScope) CriticalAlertSelectors() string {
+	selectors := []string{
+		fmt.Sprintf(`severity=~"%s"`, strings.Join(s.GetCriticalSeverities(), "|")),
+		fmt.Sprintf(`namespace=~"%s"`, s.HealthCheckNamespaceRegex()),
+	}
+	if len(s.ExcludedNamespaces) > 0 {
+		selectors = append(selectors, fmt.Sprintf(`namespace!~"%s"`, strings.Join(s.ExcludedNamespaces, "|")))
+	}
+	for _, name := range s.LabelNames() {
+		selectors = append(selectors, fmt.Sprintf(`%s=~"%s"`, name, s.LabelMatchers[name]))
+	}
+	return strings.Join(selectors, ",")
+}
+
+// IsValid returns an error if the scope cannot be used to build alert selectors
+func (s *AlertScope) IsValid() error {
+	for _, pattern := range s.Namespaces {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("config alertScope namespace pattern %q is invalid: %v", pattern, err)
+		}
+	}
+	for _, pattern := range s.ExcludedNamespaces {
+		if _, err := parseExcludedNamespace(pattern); err != nil {
+			return fmt.Errorf("config alertScope excluded namespace pattern %q is invalid: %v", pattern, err)
+		}
+	}
+	for name := range s.LabelMatchers {
+		if name == "" || name == "namespace" || name == "severity" || name == "alertname" {
+			return fmt.Errorf("config alertScope labelMatchers cannot match label %q", name)
+		}
+	}
+	return nil
+}
+
+// excludedNamespace is a namespace name, or a prefix of namespace names, excluded from the scope
+type excludedNamespace struct {
+	name   string
+	prefix bool
+}
+
+// parseExcludedNamespace parses a pattern matching a namespace name, such as ^openshift-logging$, or the
+// namespaces with a name prefix, such as ^openshift-customer-.*
+func parseExcludedNamespace(pattern string) (excludedNamespace, error) {
+	p := strings.TrimSuffix(strings.TrimPrefix(pattern, "^"), "$")
+	prefix := strings.HasSuffix(p, ".*")
+	p = strings.TrimSuffix(p, ".*")
+	re, err := syntax.Parse(p, syntax.Perl)
+	if err != nil {
+		return excludedNamespace{}, err
+	}
+	if re.Op != syntax.OpLiteral || re.Flags&syntax.FoldCase != 0 {
+		return excludedNamespace{}, fmt.Errorf("only a namespace name, or a name prefix followed by .*, can be excluded")
+	}
+	return excludedNamespace{name: string(re.Rune), prefix: prefix}, nil
+}
+
+// namespaceTrie holds the excluded namespace names character by character
+type namespaceTrie struct {
+	children map[rune]*namespaceTrie
+	// excluded is true if the name ending here is excluded
+	excluded bool
+	// excludedPrefix is true if every name beginning with the characters so far is excluded
+	excludedPrefix bool
+}
+
+func (t *namespaceTrie) add(ns excludedNamespace) {
+	node := t
+	for _, r := range ns.name {
+		if node.children == nil {
+			node.children = map[rune]*namespaceTrie{}
+		}
+		child, ok := node.children[r]
+		if !ok {
+			child = &namespaceTrie{}
+			node.children[r] = child
+		}
+		node = child
+	}
+	if ns.prefix {
+		node.excludedPrefix = true
+	} else {
+		node.excluded = true
+	}
+}
+
+// complement returns a regular expression matching the remainder of every name which continues from the
+// characters so far without being excluded
+func (t *namespaceTrie) complement() string {
+	if t.excludedPrefix {
+		return ""
+	}
+	var alternatives []string
+	if !t.excluded {
+		alternatives = append(alternatives, "")
+	}
+	runes := make([]rune, 0, len(t.children))
+	for r := range t.children {
+		runes = append(runes, r)
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+	var others []string
+	for _, r := range runes {
+		others = append(others, quoteRune(r))
+		child := t.children[r]
+		if child.excludedPrefix {
+			continue
+		}
+		alternatives = append(alternatives, quoteRune(r)+"(?:"+child.complement()+")")
+	}
+	if len(others) == 0 {
+		alternatives = append(alternatives, ".+")
+	} else {
+		alternatives = append(alternatives, "[^"+strings.Join(others, "")+"].*")
+	}
+	return strings.Join(alternatives, "|")
+}
+
+// quoteRune escapes a character so that it is matched literally, including within a character class
+func quoteRune(r rune) string {
+	if r < utf8.RuneSelf && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+		return `\` + string(r)
+	}
+	return string(r)
+}
@@ -0,0 +1,13 @@
+package alertscope
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestAlertScope(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "AlertScope Suite")
+}
@@ -0,0 +1,111 @@
+package alertscope
+
+import (
+	"regexp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AlertScope", func() {
+	Context("When no scope is configured", func() {
+		It("selects critical alerts in platform namespaces", func() {
+			scope := &AlertScope{}
+			Expect(scope.CriticalAlertSelectors()).To(Equal(`severity=~"critical",namespace=~"(^openshift.*|^kube-.*|^default$)"`))
+		})
+		It("silences alerts in platform namespaces", func() {
+			scope := &AlertScope{}
+			Expect(scope.SilenceNamespaceRegex()).To(Equal("(^openshift.*|^kube.*|^redhat.*|^default$)"))
+			Expect(isSilencedNamespace(scope, "redhat-rhoam")).To(BeTrue())
+			Expect(isSilencedNamespace(scope, "customer-default")).To(BeFalse())
+			_, ok := scope.SilenceIncludedNamespaceRegex()
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Context("When a scope is configured", func() {
+		It("selects alerts using the configured namespaces, severities and labels", func() {
+			scope := &AlertScope{
+				Namespaces:         []string{"^openshift-.*"},
+				ExcludedNamespaces: []string{"openshift-logging"},
+				CriticalSeverities: []string{"critical", "page"},
+				LabelMatchers: map[string]string{
+					"prometheus": "openshift-monitoring/k8s",
+					"cluster":    "local",
+				},
+			}
+			Expect(scope.CriticalAlertSelectors()).To(Equal(`severity=~"critical|page",namespace=~"(^openshift-.*)",namespace!~"openshift-logging",cluster=~"local",prometheus=~"openshift-monitoring/k8s"`))
+		})
+	})
+
+	Context("When namespaces are excluded from the scope", func() {
+		It("does not silence alerts in the excluded namespaces", func() {
+			scope := &AlertScope{ExcludedNamespaces: []string{"openshift-logging"}}
+			Expect(isSilencedNamespace(scope, "openshift-monitoring")).To(BeTrue())
+			Expect(isSilencedNamespace(scope, "openshift-logging")).To(BeFalse())
+			Expect(isSilencedNamespace(scope, "openshift-loggin")).To(BeTrue())
+			Expect(isSilencedNamespace(scope, "openshift-logging-operator")).To(BeTrue())
+		})
+		It("does not silence alerts in namespaces with an excluded prefix", func() {
+			scope := &AlertScope{ExcludedNamespaces: []string{"^openshift-customer-.*", "^openshift-cust$", "kube-system"}}
+			Expect(isSilencedNamespace(scope, "openshift-customer-a")).To(BeFalse())
+			Expect(isSilencedNamespace(scope, "openshift-customer-")).To(BeFalse())
+			Expect(isSilencedNamespace(scope, "openshift-cust")).To(BeFalse())
+			Expect(isSilencedNamespace(scope, "kube-system")).To(BeFalse())
+			Expect(isSilencedNamespace(scope, "openshift-customer")).To(BeTrue())
+			Expect(isSilencedNamespace(scope, "openshift-custom")).To(BeTrue())
+			Expect(isSilencedNamespace(scope, "openshift-monitoring")).To(BeTrue())
+			Expect(isSilencedNamespace(scope, "kube-public")).To(BeTrue())
+		})
+		It("matches the characters of excluded namespaces literally", func() {
+			scope := &AlertScope{ExcludedNamespaces: []string{"^openshift\\.logging$"}}
+			Expect(isSilencedNamespace(scope, "openshift.logging")).To(BeFalse())
+			Expect(isSilencedNamespace(scope, "openshift-logging")).To(BeTrue())
+		})
+		It("adds the named namespaces to the exclusions", func() {
+			scope := AlertScope{ExcludedNamespaces: []string{"openshift-logging"}}
+			excluded := scope.WithExcludedNamespaces("ns1")
+			Expect(excluded.CriticalAlertSelectors()).To(ContainSubstring(`namespace!~"openshift-logging|^ns1$"`))
+			Expect(scope.ExcludedNamespaces).To(Equal([]string{"openshift-logging"}))
+		})
+	})
+
+	Context("When validating a scope", func() {
+		It("accepts additional label matchers", func() {
+			scope := &AlertScope{LabelMatchers: map[string]string{"prometheus": ".*"}}
+			Expect(scope.IsValid()).To(Succeed())
+		})
+		It("rejects label matchers which conflict with the scope", func() {
+			scope := &AlertScope{LabelMatchers: map[string]string{"severity": "warning"}}
+			Expect(scope.IsValid()).NotTo(Succeed())
+		})
+		It("rejects invalid namespace patterns", func() {
+			scope := &AlertScope{ExcludedNamespaces: []string{"openshift-("}}
+			Expect(scope.IsValid()).NotTo(Succeed())
+		})
+		It("accepts excluded namespace names and name prefixes", func() {
+			scope := &AlertScope{ExcludedNamespaces: []string{"openshift-logging", "^ns1$", "^openshift-customer-.*"}}
+			Expect(scope.IsValid()).To(Succeed())
+		})
+		It("rejects excluded namespace patterns which are not a name or a name prefix", func() {
+			for _, pattern := range []string{"openshift-(logging|monitoring)", ".*-logging", "(?i)openshift-logging"} {
+				scope := &AlertScope{ExcludedNamespaces: []string{pattern}}
+				Expect(scope.IsValid()).NotTo(Succeed(), pattern)
+			}
+		})
+	})
+})
+
+// isSilencedNamespace returns true if alerts in the namespace match the namespace matchers of the silences
+func isSilencedNamespace(scope *AlertScope, name string) bool {
+	patterns := []string{scope.SilenceNamespaceRegex()}
+	if included, ok := scope.SilenceIncludedNamespaceRegex(); ok {
+		patterns = append(patterns, included)
+	}
+	for _, pattern := range patterns {
+		if !regexp.MustCompile("^(?:" + pattern + ")$").MatchString(name) {
+			return false
+		}
+	}
+	return true
+}
@@ -22,8 +22,17 @@ type UpgradeConfigSpec struct {
 	// Specify the desired OpenShift release
 	Desired Update `json:"desired"`
 
-	// Specify the upgrade start time
-	UpgradeAt string `json:"upgradeAt"`
+	// Specify the upgrade start time. Not required when an upgrade window or recurring schedule is specified.
+	// +kubebuilder:validation:Optional
+	UpgradeAt string `json:"upgradeAt,omitempty"`
+
+	// Specify the upgrade window, in place of an upgrade start time and the operator's upgrade window timeout
+	// +kubebuilder:validation:Optional
+	Window *UpgradeWindow `json:"window,omitempty"`
+
+	// Specify a recurring schedule of upgrade windows, in place of a single upgrade start time
+	// +kubebuilder:validation:Optional
+	Schedule *RecurringSchedule `json:"schedule,omitempty"`
 
 	// The maximum grace period granted to a node whose drain is blocked by a Pod Disruption Budget, before that drain is forced. Measured in minutes.
 	PDBForceDrainTimeout int32 `json:"PDBForceDrainTimeout"`
@@ -36,6 +45,34 @@ type UpgradeConfigSpec struct {
 	CapacityReservation bool `json:"capacityReservation,omitempty"`
 }
 
+// UpgradeWindow defines the time range within which an upgrade may commence
+type UpgradeWindow struct {
+	// Time at which the upgrade may commence (RFC3339)
+	Start string `json:"start"`
+
+	// Time by which the upgrade must have commenced (RFC3339)
+	End string `json:"end"`
+
+	// Time by which worker nodes are expected to have upgraded (RFC3339). Defaults to an estimate from the number of worker nodes.
+	// +kubebuilder:validation:Optional
+	WorkerEnd string `json:"workerEnd,omitempty"`
+}
+
+// RecurringSchedule defines recurring upgrade windows
+type RecurringSchedule struct {
+	// Standard cron expression on which each upgrade window opens
+	Cron string `json:"cron"`
+
+	// IANA time zone in which the cron expression is evaluated. Defaults to UTC.
+	// +kubebuilder:validation:Optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Length of each upgrade window. Defaults to the operator's upgrade window timeout. Measured in minutes.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	WindowLength int32 `json:"windowLength,omitempty"`
+}
+
 // UpgradeConfigStatus defines the observed state of UpgradeConfig
 type UpgradeConfigStatus struct {
 
@@ -57,6 +94,10 @@ type UpgradeHistory struct {
 
 	// Conditions is a set of Condition instances.
 	Conditions Conditions `json:"conditions,omitempty"`
+
+	// Offset by which the scheduled start of the upgrade is delayed, derived from the cluster ID to stagger upgrades across clusters
+	// +kubebuilder:validation:Optional
+	ScheduleOffset *metav1.Duration `json:"scheduleOffset,omitempty"`
 	// +kubebuilder:validation:Optional
 	StartTime *metav1.Time `json:"startTime,omitempty"`
 
@@ -66,6 +107,80 @@ type UpgradeHistory struct {
 	WorkerStartTime *metav1.Time `json:"workerStartTime,omitempty"`
 
 	WorkerCompleteTime *metav1.Time `json:"workerCompleteTime,omitempty"`
+
+	// Availability of customer workloads recorded before the upgrade commenced
+	// +kubebuilder:validation:Optional
+	WorkloadAvailability []WorkloadAvailability `json:"workloadAvailability,omitempty"`
+
+	// Customer workloads whose ready replicas dropped over the course of the upgrade
+	// +kubebuilder:validation:Optional
+	DegradedWorkloads []string `json:"degradedWorkloads,omitempty"`
+
+	// Installed operators which declare a maximum OpenShift version lower than the desired version
+	// +kubebuilder:validation:Optional
+	IncompatibleOperators []string `json:"incompatibleOperators,omitempty"`
+
+	// APIs removed in the desired version which are still in use, and the users calling them
+	// +kubebuilder:validation:Optional
+	RemovedAPIUsage []string `json:"removedAPIUsage,omitempty"`
+
+	// Administrator acknowledgements given for the desired version, and who gave them
+	// +kubebuilder:validation:Optional
+	AdminAcks []string `json:"adminAcks,omitempty"`
+
+	// Administrator acknowledgements required by the desired version which have not been given
+	// +kubebuilder:validation:Optional
+	PendingAdminAcks []string `json:"pendingAdminAcks,omitempty"`
+
+	// Risks of a conditional update to the desired version which apply to the cluster
+	// +kubebuilder:validation:Optional
+	ConditionalUpdateRisks []string `json:"conditionalUpdateRisks,omitempty"`
+
+	// Release image of the desired version, when validated against an update graph source the CVO does not use
+	// +kubebuilder:validation:Optional
+	ReleaseImage string `json:"releaseImage,omitempty"`
+
+	// PodDisruptionBudgets which allowed no disruptions before the upgrade commenced, and so would block node drains
+	// +kubebuilder:validation:Optional
+	BlockingPodDisruptionBudgets []string `json:"blockingPodDisruptionBudgets,omitempty"`
+
+	// Actions forcefully taken on pods by node drain strategies over the course of the upgrade
+	// +kubebuilder:validation:Optional
+	ForcedDrainActions []ForcedDrainAction `json:"forcedDrainActions,omitempty"`
+}
+
+// ForcedDrainAction records an action forcefully taken on a pod to drain it from a node
+type ForcedDrainAction struct {
+	// Node the pod was drained from
+	Node string `json:"node"`
+	// Namespace of the pod
+	Namespace string `json:"namespace"`
+	// Name of the pod
+	Pod string `json:"pod"`
+	// Name of the drain strategy which took the action
+	Strategy string `json:"strategy"`
+	// Action taken on the pod, one of ForceDeleted or FinalizersRemoved
+	Action string `json:"action"`
+	// Time the action was taken
+	Time metav1.Time `json:"time"`
+	// PodDisruptionBudget covering the pod, if the strategy was selecting pods covered by one
+	// +kubebuilder:validation:Optional
+	PodDisruptionBudget string `json:"podDisruptionBudget,omitempty"`
+}
+
+// WorkloadAvailability records the ready replicas of a customer workload
+type WorkloadAvailability struct {
+	// Kind of the workload, one of Deployment or StatefulSet
+	Kind string `json:"kind"`
+	// Namespace of the workload
+	Namespace string `json:"namespace"`
+	// Name of the workload
+	Name string `json:"name"`
+	// Number of ready replicas of the workload
+	ReadyReplicas int32 `json:"readyReplicas"`
+	// Number of replicas desired by the workload's spec
+	// +kubebuilder:validation:Optional
+	Replicas int32 `json:"replicas,omitempty"`
 }
 
 // UpgradeConditionType is a Go string type.
@@ -104,6 +219,8 @@ const (
 	UpgradeDelayedCheck UpgradeConditionType = "UpgradeDelayedCheck"
 	// UpgradeValidated is an UpgradeConditionType
 	UpgradeValidated UpgradeConditionType = "Validation"
+	// UpgradeWindowCheck is an UpgradeConditionType
+	UpgradeWindowCheck UpgradeConditionType = "UpgradeWindowCheck"
 	// UpgradePreHealthCheck is an UpgradeConditionType
 	UpgradePreHealthCheck UpgradeConditionType = "PreHealthCheck"
 	// ExtDepAvailabilityCheck is an UpgradeConditionType
@@ -122,6 +239,10 @@ const (
 	WorkersMaintWindow UpgradeConditionType = "WorkersMaintWindow"
 	// AllWorkerNodesUpgraded is an UpgradeConditionType
 	AllWorkerNodesUpgraded UpgradeConditionType = "AllWorkerNodesUpgraded"
+	// WorkloadAvailabilitySnapshot is an UpgradeConditionType
+	WorkloadAvailabilitySnapshot UpgradeConditionType = "WorkloadAvailabilitySnapshot"
+	// WorkloadAvailabilityCheck is an UpgradeConditionType
+	WorkloadAvailabilityCheck UpgradeConditionType = "WorkloadAvailabilityCheck"
 	// RemoveExtraScaledNodes is an UpgradeConditionType
 	RemoveExtraScaledNodes UpgradeConditionType = "RemoveExtraScaledNodes"
 	// UpdateSubscriptions is an UpgradeConditionType
@@ -192,6 +313,9 @@ type Update struct {
 	Version string `json:"version"`
 	// Channel used for upgrades
 	Channel string `json:"channel"`
+	// Names of conditional update risks which are accepted for this upgrade
+	// +kubebuilder:validation:Optional
+	AcceptedRisks []string `json:"acceptedRisks,omitempty"`
 }
 
 // IsTrue Condition whether the condition status is "True".
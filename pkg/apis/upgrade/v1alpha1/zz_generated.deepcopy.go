@@ -5,6 +5,7 @@
 package v1alpha1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -30,9 +31,47 @@ func (in Conditions) DeepCopy() Conditions {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForcedDrainAction) DeepCopyInto(out *ForcedDrainAction) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForcedDrainAction.
+func (in *ForcedDrainAction) DeepCopy() *ForcedDrainAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ForcedDrainAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecurringSchedule) DeepCopyInto(out *RecurringSchedule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecurringSchedule.
+func (in *RecurringSchedule) DeepCopy() *RecurringSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(RecurringSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Update) DeepCopyInto(out *Update) {
 	*out = *in
+	if in.AcceptedRisks != nil {
+		in, out := &in.AcceptedRisks, &out.AcceptedRisks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -61,7 +100,7 @@ func (in *UpgradeConfig) DeepCopyInto(out *UpgradeConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -120,7 +159,17 @@ func (in *UpgradeConfigList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UpgradeConfigSpec) DeepCopyInto(out *UpgradeConfigSpec) {
 	*out = *in
-	out.Desired = in.Desired
+	in.Desired.DeepCopyInto(&out.Desired)
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(RecurringSchedule)
+		**out = **in
+	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(UpgradeWindow)
+		**out = **in
+	}
 	return
 }
 
@@ -189,6 +238,11 @@ func (in *UpgradeHistory) DeepCopyInto(out *UpgradeHistory) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ScheduleOffset != nil {
+		in, out := &in.ScheduleOffset, &out.ScheduleOffset
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	if in.StartTime != nil {
 		in, out := &in.StartTime, &out.StartTime
 		*out = (*in).DeepCopy()
@@ -205,6 +259,53 @@ func (in *UpgradeHistory) DeepCopyInto(out *UpgradeHistory) {
 		in, out := &in.WorkerCompleteTime, &out.WorkerCompleteTime
 		*out = (*in).DeepCopy()
 	}
+	if in.WorkloadAvailability != nil {
+		in, out := &in.WorkloadAvailability, &out.WorkloadAvailability
+		*out = make([]WorkloadAvailability, len(*in))
+		copy(*out, *in)
+	}
+	if in.DegradedWorkloads != nil {
+		in, out := &in.DegradedWorkloads, &out.DegradedWorkloads
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncompatibleOperators != nil {
+		in, out := &in.IncompatibleOperators, &out.IncompatibleOperators
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemovedAPIUsage != nil {
+		in, out := &in.RemovedAPIUsage, &out.RemovedAPIUsage
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdminAcks != nil {
+		in, out := &in.AdminAcks, &out.AdminAcks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingAdminAcks != nil {
+		in, out := &in.PendingAdminAcks, &out.PendingAdminAcks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConditionalUpdateRisks != nil {
+		in, out := &in.ConditionalUpdateRisks, &out.ConditionalUpdateRisks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BlockingPodDisruptionBudgets != nil {
+		in, out := &in.BlockingPodDisruptionBudgets, &out.BlockingPodDisruptionBudgets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ForcedDrainActions != nil {
+		in, out := &in.ForcedDrainActions, &out.ForcedDrainActions
+		*out = make([]ForcedDrainAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -217,3 +318,35 @@ func (in *UpgradeHistory) DeepCopy() *UpgradeHistory {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeWindow) DeepCopyInto(out *UpgradeWindow) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeWindow.
+func (in *UpgradeWindow) DeepCopy() *UpgradeWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadAvailability) DeepCopyInto(out *WorkloadAvailability) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadAvailability.
+func (in *WorkloadAvailability) DeepCopy() *WorkloadAvailability {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadAvailability)
+	in.DeepCopyInto(out)
+	return out
+}
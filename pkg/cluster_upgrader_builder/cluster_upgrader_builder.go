@@ -3,6 +3,7 @@ package cluster_upgrader_builder
 import (
 	"github.com/go-logr/logr"
 	"github.com/openshift/managed-upgrade-operator/pkg/eventmanager"
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
@@ -25,29 +26,33 @@ type ClusterUpgraderBuilder interface {
 	NewClient(client.Client, configmanager.ConfigManager, metrics.Metrics, eventmanager.EventManager, upgradev1alpha1.UpgradeType) (ClusterUpgrader, error)
 }
 
-// NewBuilder returns a clusterUpgraderBuilder
-func NewBuilder() ClusterUpgraderBuilder {
-	return &clusterUpgraderBuilder{}
+// NewBuilder returns a clusterUpgraderBuilder, whose upgraders drain nodes with the given clientset
+func NewBuilder(kubeClient kubernetes.Interface) ClusterUpgraderBuilder {
+	return &clusterUpgraderBuilder{
+		kubeClient: kubeClient,
+	}
 }
 
-type clusterUpgraderBuilder struct{}
+type clusterUpgraderBuilder struct {
+	kubeClient kubernetes.Interface
+}
 
 func (cub *clusterUpgraderBuilder) NewClient(c client.Client, cfm configmanager.ConfigManager, mc metrics.Metrics, nc eventmanager.EventManager, upgradeType upgradev1alpha1.UpgradeType) (ClusterUpgrader, error) {
 	switch upgradeType {
 	case upgradev1alpha1.OSD:
-		cu, err := osd.NewClient(c, cfm, mc, nc)
+		cu, err := osd.NewClient(c, cfm, mc, nc, cub.kubeClient)
 		if err != nil {
 			return nil, err
 		}
 		return cu, nil
 	case upgradev1alpha1.ARO:
-		cu, err := aro.NewClient(c, cfm, mc, nc)
+		cu, err := aro.NewClient(c, cfm, mc, nc, cub.kubeClient)
 		if err != nil {
 			return nil, err
 		}
 		return cu, nil
 	default:
-		cu, err := osd.NewClient(c, cfm, mc, nc)
+		cu, err := osd.NewClient(c, cfm, mc, nc, cub.kubeClient)
 		if err != nil {
 			return nil, err
 		}
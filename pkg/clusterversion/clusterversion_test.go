@@ -10,8 +10,10 @@ import (
 	"github.com/openshift/managed-upgrade-operator/util/mocks"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
 	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
@@ -142,6 +144,7 @@ var _ = Describe("ClusterVersion client and utils", func() {
 						mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
 							func(ctx context.Context, cv *configv1.ClusterVersion) error {
 								Expect(cv.Spec.DesiredUpdate.Version).To(Equal(upgradeConfig.Spec.Desired.Version))
+								Expect(cv.Spec.DesiredUpdate.Image).To(Equal("quay.io/dummy-image-for-test"))
 								Expect(cv.Spec.Channel).To(Equal(upgradeConfig.Spec.Desired.Channel))
 								return nil
 							}),
@@ -152,6 +155,82 @@ var _ = Describe("ClusterVersion client and utils", func() {
 				})
 			})
 
+			Context("When the desired version was validated against an update graph source the CVO does not use", func() {
+				It("Sets the desired release image without waiting for it to be an available update", func() {
+					upgradeConfig.Status.History = []upgradev1alpha1.UpgradeHistory{
+						{
+							Version:      upgradeConfig.Spec.Desired.Version,
+							ReleaseImage: "quay.io/openshift-release-dev/ocp-release@sha256:bbb",
+						},
+					}
+					clusterVersion := configv1.ClusterVersion{
+						Spec: configv1.ClusterVersionSpec{
+							Channel: upgradeConfig.Spec.Desired.Channel,
+						},
+					}
+					gomock.InOrder(
+						mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, clusterVersion).Return(nil),
+						mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+							func(ctx context.Context, cv *configv1.ClusterVersion) error {
+								Expect(cv.Spec.DesiredUpdate.Version).To(Equal(upgradeConfig.Spec.Desired.Version))
+								Expect(cv.Spec.DesiredUpdate.Image).To(Equal("quay.io/openshift-release-dev/ocp-release@sha256:bbb"))
+								return nil
+							}),
+					)
+					isCompleted, err := cvClient.EnsureDesiredVersion(upgradeConfig)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(isCompleted).To(BeTrue())
+				})
+			})
+
+			Context("When the desired version is a conditional update", func() {
+				It("Sets the desired release image of the conditional update", func() {
+					clusterVersion := configv1.ClusterVersion{
+						Spec: configv1.ClusterVersionSpec{
+							Channel: upgradeConfig.Spec.Desired.Channel,
+						},
+					}
+					gomock.InOrder(
+						mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, clusterVersion).Return(nil),
+						mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+							func(ctx context.Context, key client.ObjectKey, obj *unstructured.Unstructured) error {
+								obj.Object["status"] = map[string]interface{}{
+									"conditionalUpdates": []interface{}{
+										map[string]interface{}{
+											"release": map[string]interface{}{"version": upgradeConfig.Spec.Desired.Version, "image": "quay.io/dummy-image-for-test"},
+											"risks":   []interface{}{map[string]interface{}{"name": "SomeRisk"}},
+										},
+									},
+								}
+								return nil
+							}),
+						mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+							func(ctx context.Context, cv *configv1.ClusterVersion) error {
+								Expect(cv.Spec.DesiredUpdate.Version).To(Equal(upgradeConfig.Spec.Desired.Version))
+								Expect(cv.Spec.DesiredUpdate.Image).To(Equal("quay.io/dummy-image-for-test"))
+								return nil
+							}),
+					)
+					isCompleted, err := cvClient.EnsureDesiredVersion(upgradeConfig)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(isCompleted).To(BeTrue())
+				})
+				It("Waits while the CVO offers no update to the desired version", func() {
+					clusterVersion := configv1.ClusterVersion{
+						Spec: configv1.ClusterVersionSpec{
+							Channel: upgradeConfig.Spec.Desired.Channel,
+						},
+					}
+					gomock.InOrder(
+						mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, clusterVersion).Return(nil),
+						mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					)
+					isCompleted, err := cvClient.EnsureDesiredVersion(upgradeConfig)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(isCompleted).To(BeFalse())
+				})
+			})
+
 			Context("When the cluster's desired version does not match the UpgradeConfig's", func() {
 				It("Sets the desired version to that of the UpgradeConfig's", func() {
 					clusterVersion := configv1.ClusterVersion{
@@ -175,6 +254,7 @@ var _ = Describe("ClusterVersion client and utils", func() {
 						mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
 							func(ctx context.Context, cv *configv1.ClusterVersion) error {
 								Expect(cv.Spec.DesiredUpdate.Version).To(Equal(upgradeConfig.Spec.Desired.Version))
+								Expect(cv.Spec.DesiredUpdate.Image).To(Equal("quay.io/dummy-image-for-test"))
 								Expect(cv.Spec.Channel).To(Equal(upgradeConfig.Spec.Desired.Channel))
 								return nil
 							}),
@@ -265,6 +345,74 @@ var _ = Describe("ClusterVersion client and utils", func() {
 					Expect(len(result.Degraded)).To(Equal(2))
 				})
 			})
+
+			Context("When operators are not upgradeable", func() {
+				var operatorList configv1.ClusterOperatorList
+
+				JustBeforeEach(func() {
+					operatorList = configv1.ClusterOperatorList{
+						Items: []configv1.ClusterOperator{
+							{
+								ObjectMeta: metav1.ObjectMeta{Name: "operator1"},
+								Status: configv1.ClusterOperatorStatus{
+									Conditions: []configv1.ClusterOperatorStatusCondition{
+										{Type: configv1.OperatorUpgradeable, Status: configv1.ConditionFalse, Reason: "AdminAckRequired"},
+									},
+								},
+							},
+							{
+								ObjectMeta: metav1.ObjectMeta{Name: "operator2"},
+								Status: configv1.ClusterOperatorStatus{
+									Conditions: []configv1.ClusterOperatorStatusCondition{
+										{Type: configv1.OperatorUpgradeable, Status: configv1.ConditionTrue},
+									},
+								},
+							},
+						},
+					}
+				})
+				It("will indicate which ClusterOperators are not upgradeable and why", func() {
+					gomock.InOrder(
+						mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, operatorList),
+					)
+					result, err := cvClient.HasNonUpgradeableOperators()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.NonUpgradeable).To(Equal([]string{"operator1 (AdminAckRequired)"}))
+				})
+			})
+		})
+	})
+
+	Context("When comparing versions", func() {
+		var clusterVersion *configv1.ClusterVersion
+
+		BeforeEach(func() {
+			clusterVersion = &configv1.ClusterVersion{
+				Status: configv1.ClusterVersionStatus{
+					History: []configv1.UpdateHistory{
+						{State: configv1.CompletedUpdate, Version: "4.5.16", CompletionTime: &metav1.Time{}},
+					},
+				},
+			}
+		})
+		It("will identify a minor upgrade", func() {
+			result, err := IsMinorUpgrade(clusterVersion, "4.6.1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+		It("will treat a major upgrade as a minor upgrade", func() {
+			result, err := IsMinorUpgrade(clusterVersion, "5.0.0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+		It("will not identify a patch upgrade as a minor upgrade", func() {
+			result, err := IsMinorUpgrade(clusterVersion, "4.5.17")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeFalse())
+		})
+		It("will error if the desired version is not semver", func() {
+			_, err := IsMinorUpgrade(clusterVersion, "fakeVersion")
+			Expect(err).To(HaveOccurred())
 		})
 	})
 })
@@ -0,0 +1,71 @@
+package clusterversion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConditionalUpdate is an update the CVO only recommends while none of its risks apply to the cluster
+type ConditionalUpdate struct {
+	Release configv1.Release        `json:"release"`
+	Risks   []ConditionalUpdateRisk `json:"risks"`
+}
+
+// ConditionalUpdateRisk describes a risk of a conditional update and how to tell if it applies to the cluster
+type ConditionalUpdateRisk struct {
+	Name          string                              `json:"name"`
+	URL           string                              `json:"url"`
+	Message       string                              `json:"message"`
+	MatchingRules []ConditionalUpdateRiskMatchingRule `json:"matchingRules"`
+}
+
+// ConditionalUpdateRiskMatchingRule is a rule which matches the clusters a risk applies to
+type ConditionalUpdateRiskMatchingRule struct {
+	Type   string                  `json:"type"`
+	PromQL *PromQLClusterCondition `json:"promql,omitempty"`
+}
+
+// PromQLClusterCondition matches clusters where a PromQL query evaluates to 1
+type PromQLClusterCondition struct {
+	PromQL string `json:"promql"`
+}
+
+// String returns the risk's name and URL
+func (r ConditionalUpdateRisk) String() string {
+	return fmt.Sprintf("%s (%s)", r.Name, r.URL)
+}
+
+// GetConditionalUpdates returns the conditional updates the CVO offers the cluster. The vendored ClusterVersion
+// API predates conditional updates, so they are read from the ClusterVersion's unstructured status.
+func GetConditionalUpdates(c client.Client) ([]ConditionalUpdate, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(configv1.GroupVersion.WithKind("ClusterVersion"))
+	err := c.Get(context.TODO(), types.NamespacedName{Name: OSD_CV_NAME}, u)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, found, err := unstructured.NestedSlice(u.Object, "status", "conditionalUpdates")
+	if err != nil {
+		return nil, fmt.Errorf("unable to read conditional updates: %v", err)
+	}
+	if !found {
+		return []ConditionalUpdate{}, nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	updates := []ConditionalUpdate{}
+	err = json.Unmarshal(data, &updates)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse conditional updates: %v", err)
+	}
+	return updates, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/blang/semver"
 	configv1 "github.com/openshift/api/config/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -25,6 +26,7 @@ type ClusterVersion interface {
 	EnsureDesiredVersion(uc *upgradev1alpha1.UpgradeConfig) (bool, error)
 	HasUpgradeCompleted(*configv1.ClusterVersion, *upgradev1alpha1.UpgradeConfig) bool
 	HasDegradedOperators() (*HasDegradedOperatorsResult, error)
+	HasNonUpgradeableOperators() (*HasNonUpgradeableOperatorsResult, error)
 }
 
 // ClusterVersionBuilder returns a ClusterVersion interface
@@ -86,19 +88,37 @@ func (c *clusterVersionClient) EnsureDesiredVersion(uc *upgradev1alpha1.UpgradeC
 		}
 	}
 
-	// The CVO may need time sync the version before launching the upgrade
-	updateAvailable := false
-	for _, update := range clusterVersion.Status.AvailableUpdates {
-		if update.Version == desired.Version && update.Image != "" {
-			updateAvailable = true
+	// Updates validated against an update graph source the CVO does not use are requested by release image.
+	// Otherwise the CVO may need time sync the version before launching the upgrade
+	var image string
+	history := uc.Status.History.GetHistory(desired.Version)
+	if history != nil && history.ReleaseImage != "" {
+		image = history.ReleaseImage
+	} else {
+		for _, update := range clusterVersion.Status.AvailableUpdates {
+			if update.Version == desired.Version && update.Image != "" {
+				image = update.Image
+			}
 		}
 	}
-	if !updateAvailable {
+	// Otherwise the desired version may be a conditional update, whose risks were accepted during validation
+	if image == "" {
+		conditionalUpdates, err := GetConditionalUpdates(c.client)
+		if err != nil {
+			return false, err
+		}
+		for _, update := range conditionalUpdates {
+			if update.Release.Version == desired.Version && update.Release.Image != "" {
+				image = update.Release.Image
+			}
+		}
+	}
+	if image == "" {
 		return false, nil
 	}
 
 	clusterVersion.Spec.Overrides = []configv1.ComponentOverride{}
-	clusterVersion.Spec.DesiredUpdate = &configv1.Update{Version: uc.Spec.Desired.Version}
+	clusterVersion.Spec.DesiredUpdate = &configv1.Update{Version: uc.Spec.Desired.Version, Image: image}
 	err = c.client.Update(context.TODO(), clusterVersion)
 	if err != nil {
 		return false, err
@@ -136,6 +156,36 @@ func (c *clusterVersionClient) HasDegradedOperators() (*HasDegradedOperatorsResu
 	}, err
 }
 
+// HasNonUpgradeableOperatorsResult holds fields that describe operators blocking a minor upgrade
+type HasNonUpgradeableOperatorsResult struct {
+	NonUpgradeable []string
+}
+
+// HasNonUpgradeableOperators returns the ClusterOperators reporting Upgradeable=False, along with their reasons
+func (c *clusterVersionClient) HasNonUpgradeableOperators() (*HasNonUpgradeableOperatorsResult, error) {
+	operatorList := &configv1.ClusterOperatorList{}
+	err := c.client.List(context.TODO(), operatorList, []client.ListOption{}...)
+	if err != nil {
+		return &HasNonUpgradeableOperatorsResult{
+			NonUpgradeable: []string{},
+		}, err
+	}
+
+	nonUpgradeableOperators := []string{}
+	for _, co := range operatorList.Items {
+		for _, condition := range co.Status.Conditions {
+			if condition.Type == configv1.OperatorUpgradeable && condition.Status == configv1.ConditionFalse {
+				nonUpgradeableOperators = append(nonUpgradeableOperators, fmt.Sprintf("%s (%s)", co.Name, condition.Reason))
+				break
+			}
+		}
+	}
+
+	return &HasNonUpgradeableOperatorsResult{
+		NonUpgradeable: nonUpgradeableOperators,
+	}, nil
+}
+
 func (c *clusterVersionClient) HasUpgradeCompleted(cv *configv1.ClusterVersion, uc *upgradev1alpha1.UpgradeConfig) bool {
 	isCompleted := false
 	for _, c := range cv.Status.History {
@@ -204,3 +254,22 @@ func GetCurrentVersion(clusterVersion *configv1.ClusterVersion) (string, error)
 
 	return gotVersion, nil
 }
+
+// IsMinorUpgrade returns true if the desired version is a newer major or minor version than the current cluster version
+func IsMinorUpgrade(clusterVersion *configv1.ClusterVersion, desiredVersion string) (bool, error) {
+	version, err := GetCurrentVersion(clusterVersion)
+	if err != nil {
+		return false, err
+	}
+
+	current, err := semver.Parse(version)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse current version %s as semver", version)
+	}
+	desired, err := semver.Parse(desiredVersion)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse desired version %s as semver", desiredVersion)
+	}
+
+	return desired.Major > current.Major || (desired.Major == current.Major && desired.Minor > current.Minor), nil
+}
@@ -80,6 +80,21 @@ func (mr *MockClusterVersionMockRecorder) HasDegradedOperators() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasDegradedOperators", reflect.TypeOf((*MockClusterVersion)(nil).HasDegradedOperators))
 }
 
+// HasNonUpgradeableOperators mocks base method
+func (m *MockClusterVersion) HasNonUpgradeableOperators() (*clusterversion.HasNonUpgradeableOperatorsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasNonUpgradeableOperators")
+	ret0, _ := ret[0].(*clusterversion.HasNonUpgradeableOperatorsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasNonUpgradeableOperators indicates an expected call of HasNonUpgradeableOperators
+func (mr *MockClusterVersionMockRecorder) HasNonUpgradeableOperators() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasNonUpgradeableOperators", reflect.TypeOf((*MockClusterVersion)(nil).HasNonUpgradeableOperators))
+}
+
 // HasUpgradeCommenced mocks base method
 func (m *MockClusterVersion) HasUpgradeCommenced(arg0 *v1alpha1.UpgradeConfig) (bool, error) {
 	m.ctrl.T.Helper()
@@ -3,14 +3,21 @@ package nodekeeper
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/openshift/managed-upgrade-operator/util"
 
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
 	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -29,6 +36,10 @@ import (
 
 var log = logf.Log.WithName("controller_nodekeeper")
 
+// maxForcedDrainActions bounds the forced drain actions recorded per upgrade, so the UpgradeConfig stays within
+// the API server's object size limit however many pods are forced
+const maxForcedDrainActions = 1000
+
 // Add creates a new NodeKeeper Controller and adds it to the Manager. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
@@ -37,17 +48,44 @@ func Add(mgr manager.Manager) error {
 	if err != nil {
 		return err
 	}
-	return add(mgr, newReconciler(mgr, c))
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return err
+	}
+
+	// The manager's cache is restricted to the operator namespace, so drain strategies read the pods,
+	// PodDisruptionBudgets, PersistentVolumeClaims and VolumeAttachments across the cluster from a dedicated
+	// cache, indexed by the node pods are scheduled to
+	podCache, err := cache.New(kubeConfig, cache.Options{Scheme: mgr.GetScheme(), Mapper: mgr.GetRESTMapper()})
+	if err != nil {
+		return err
+	}
+	err = drain.IndexPodsByNodeName(context.TODO(), podCache)
+	if err != nil {
+		return err
+	}
+	for _, obj := range []client.Object{&policyv1beta1.PodDisruptionBudget{}, &corev1.PersistentVolumeClaim{}, &storagev1.VolumeAttachment{}} {
+		_, err = podCache.GetInformer(context.TODO(), obj)
+		if err != nil {
+			return err
+		}
+	}
+	err = mgr.Add(podCache)
+	if err != nil {
+		return err
+	}
+
+	return add(mgr, newReconciler(mgr, drain.NewCachedClient(c, podCache), kubeClient))
 }
 
 // newReconciler returns a new reconcile.Reconciler
-func newReconciler(mgr manager.Manager, client client.Client) reconcile.Reconciler {
+func newReconciler(mgr manager.Manager, client client.Client, kubeClient kubernetes.Interface) reconcile.Reconciler {
 	return &ReconcileNodeKeeper{
 		client:                      client,
 		configManagerBuilder:        configmanager.NewBuilder(),
 		machinery:                   machinery.NewMachinery(),
 		metricsClientBuilder:        metrics.NewBuilder(),
-		drainstrategyBuilder:        drain.NewBuilder(),
+		drainstrategyBuilder:        drain.NewBuilder(kubeClient),
 		upgradeConfigManagerBuilder: upgradeconfigmanager.NewBuilder(),
 		scheme:                      mgr.GetScheme(),
 	}
@@ -135,6 +173,8 @@ func (r *ReconcileNodeKeeper) Reconcile(ctx context.Context, request reconcile.R
 	}
 	if !result.IsCordoned {
 		metricsClient.ResetMetricNodeDrainFailed(node.Name)
+		metricsClient.ResetMetricNodeDrainProtectedPods(node.Name)
+		metricsClient.ResetMetricNodeDrainStuckVolumeAttachments(node.Name)
 		return reconcile.Result{}, nil
 	}
 
@@ -155,8 +195,16 @@ func (r *ReconcileNodeKeeper) Reconcile(ctx context.Context, request reconcile.R
 		return reconcile.Result{}, err
 	}
 	res, err := drainStrategy.Execute(node)
+	var forcedActions []upgradev1alpha1.ForcedDrainAction
 	for _, r := range res {
 		reqLogger.Info(r.Message)
+		forcedActions = append(forcedActions, r.ForcedActions...)
+	}
+	if len(forcedActions) > 0 {
+		// The pods have already been forced, so failing to record it should not stop the drain
+		if recordErr := r.recordForcedDrainActions(uc, forcedActions); recordErr != nil {
+			reqLogger.Error(recordErr, fmt.Sprintf("Unable to record forced drain actions for node %s", node.Name))
+		}
 	}
 	if err != nil {
 		return reconcile.Result{}, err
@@ -169,8 +217,60 @@ func (r *ReconcileNodeKeeper) Reconcile(ctx context.Context, request reconcile.R
 	if hasFailed {
 		reqLogger.Info(fmt.Sprintf("Node drain timed out %s. Alerting.", node.Name))
 		metricsClient.UpdateMetricNodeDrainFailed(node.Name)
+
+		// Drain protected pods are never forced from the node, so alert on them instead
+		protectedPods, err := drainStrategy.GetProtectedPods(node)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if len(protectedPods.Items) > 0 {
+			var names []string
+			for _, p := range protectedPods.Items {
+				names = append(names, fmt.Sprintf("%s/%s", p.Namespace, p.Name))
+			}
+			reqLogger.Info(fmt.Sprintf("Node %s drain is blocked by drain protected pod(s) %s. Alerting.", node.Name, strings.Join(names, ",")))
+		}
+		metricsClient.UpdateMetricNodeDrainProtectedPods(node.Name, len(protectedPods.Items))
+
+		// Volumes stuck attached to the node are not released by any drain strategy acting on pods
+		stuckAttachments, err := drainStrategy.GetStuckVolumeAttachments(node)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if len(stuckAttachments) > 0 {
+			var names []string
+			for _, va := range stuckAttachments {
+				names = append(names, fmt.Sprintf("%s (%s)", va.Name, *va.Spec.Source.PersistentVolumeName))
+			}
+			reqLogger.Info(fmt.Sprintf("Node %s drain is blocked by stuck VolumeAttachment(s) %s. Alerting.", node.Name, strings.Join(names, ",")))
+		}
+		metricsClient.UpdateMetricNodeDrainStuckVolumeAttachments(node.Name, len(stuckAttachments))
 		return reconcile.Result{RequeueAfter: time.Minute * 1}, nil
 	}
+	metricsClient.ResetMetricNodeDrainProtectedPods(node.Name)
+	metricsClient.ResetMetricNodeDrainStuckVolumeAttachments(node.Name)
 
 	return reconcile.Result{RequeueAfter: time.Minute * 1}, nil
 }
+
+// recordForcedDrainActions appends the forced drain actions to the UpgradeConfig's history of the version being
+// upgraded to, so they survive operator restarts and log rotation. Only the most recent actions are kept.
+func (r *ReconcileNodeKeeper) recordForcedDrainActions(uc *upgradev1alpha1.UpgradeConfig, actions []upgradev1alpha1.ForcedDrainAction) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		instance := &upgradev1alpha1.UpgradeConfig{}
+		err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: uc.Namespace, Name: uc.Name}, instance)
+		if err != nil {
+			return err
+		}
+		history := instance.Status.History.GetHistory(instance.Spec.Desired.Version)
+		if history == nil {
+			return nil
+		}
+		history.ForcedDrainActions = append(history.ForcedDrainActions, actions...)
+		if len(history.ForcedDrainActions) > maxForcedDrainActions {
+			history.ForcedDrainActions = history.ForcedDrainActions[len(history.ForcedDrainActions)-maxForcedDrainActions:]
+		}
+		instance.Status.History.SetHistory(*history)
+		return r.client.Status().Update(context.TODO(), instance)
+	})
+}
@@ -20,6 +20,8 @@ import (
 	mockUCMgr "github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager/mocks"
 	"github.com/openshift/managed-upgrade-operator/util/mocks"
 	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	. "github.com/onsi/ginkgo"
@@ -137,6 +139,10 @@ var _ = Describe("NodeKeeperController", func() {
 					mockDrainStrategy.EXPECT().Execute(gomock.Any()).Return([]*drain.DrainStrategyResult{}, nil),
 					mockDrainStrategy.EXPECT().HasFailed(gomock.Any()).Return(true, nil),
 					mockMetricsClient.EXPECT().UpdateMetricNodeDrainFailed(gomock.Any()).Times(1),
+					mockDrainStrategy.EXPECT().GetProtectedPods(gomock.Any()).Return(&corev1.PodList{}, nil),
+					mockMetricsClient.EXPECT().UpdateMetricNodeDrainProtectedPods(gomock.Any(), 0),
+					mockDrainStrategy.EXPECT().GetStuckVolumeAttachments(gomock.Any()).Return(nil, nil),
+					mockMetricsClient.EXPECT().UpdateMetricNodeDrainStuckVolumeAttachments(gomock.Any(), 0),
 					mockMetricsClient.EXPECT().ResetMetricNodeDrainFailed(gomock.Any()).Times(0),
 				)
 				result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: testNodeName})
@@ -144,6 +150,104 @@ var _ = Describe("NodeKeeperController", func() {
 				Expect(result.Requeue).To(BeFalse())
 				Expect(result.RequeueAfter).To(Not(BeNil()))
 			})
+			It("should alert on drain protected pods blocking a node drain which takes too long", func() {
+				protectedPods := &corev1.PodList{
+					Items: []corev1.Pod{
+						{ObjectMeta: metav1.ObjectMeta{Name: "stateful-0", Namespace: "database"}},
+					},
+				}
+				gomock.InOrder(
+					mockUpgradeConfigManagerBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUpgradeConfigManager, nil),
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true}, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), testNodeName, gomock.Any()).Times(1),
+					mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}}),
+					mockMetricsBuilder.EXPECT().NewClient(gomock.Any()).Return(mockMetricsClient, nil),
+					mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+					mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, config),
+					mockDrainStrategyBuilder.EXPECT().NewNodeDrainStrategy(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockDrainStrategy, nil),
+					mockDrainStrategy.EXPECT().Execute(gomock.Any()).Return([]*drain.DrainStrategyResult{}, nil),
+					mockDrainStrategy.EXPECT().HasFailed(gomock.Any()).Return(true, nil),
+					mockMetricsClient.EXPECT().UpdateMetricNodeDrainFailed(gomock.Any()).Times(1),
+					mockDrainStrategy.EXPECT().GetProtectedPods(gomock.Any()).Return(protectedPods, nil),
+					mockMetricsClient.EXPECT().UpdateMetricNodeDrainProtectedPods(gomock.Any(), 1),
+					mockDrainStrategy.EXPECT().GetStuckVolumeAttachments(gomock.Any()).Return(nil, nil),
+					mockMetricsClient.EXPECT().UpdateMetricNodeDrainStuckVolumeAttachments(gomock.Any(), 0),
+				)
+				result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: testNodeName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Not(BeZero()))
+			})
+			It("should alert on stuck volume attachments blocking a node drain which takes too long", func() {
+				pvName := "pv-0"
+				stuckAttachments := []storagev1.VolumeAttachment{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "csi-0"},
+						Spec: storagev1.VolumeAttachmentSpec{
+							NodeName: testNodeName.Name,
+							Source:   storagev1.VolumeAttachmentSource{PersistentVolumeName: &pvName},
+						},
+					},
+				}
+				gomock.InOrder(
+					mockUpgradeConfigManagerBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUpgradeConfigManager, nil),
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true}, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), testNodeName, gomock.Any()).Times(1),
+					mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}}),
+					mockMetricsBuilder.EXPECT().NewClient(gomock.Any()).Return(mockMetricsClient, nil),
+					mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+					mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, config),
+					mockDrainStrategyBuilder.EXPECT().NewNodeDrainStrategy(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockDrainStrategy, nil),
+					mockDrainStrategy.EXPECT().Execute(gomock.Any()).Return([]*drain.DrainStrategyResult{}, nil),
+					mockDrainStrategy.EXPECT().HasFailed(gomock.Any()).Return(true, nil),
+					mockMetricsClient.EXPECT().UpdateMetricNodeDrainFailed(gomock.Any()).Times(1),
+					mockDrainStrategy.EXPECT().GetProtectedPods(gomock.Any()).Return(&corev1.PodList{}, nil),
+					mockMetricsClient.EXPECT().UpdateMetricNodeDrainProtectedPods(gomock.Any(), 0),
+					mockDrainStrategy.EXPECT().GetStuckVolumeAttachments(gomock.Any()).Return(stuckAttachments, nil),
+					mockMetricsClient.EXPECT().UpdateMetricNodeDrainStuckVolumeAttachments(gomock.Any(), 1),
+				)
+				result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: testNodeName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Not(BeZero()))
+			})
+			It("should record forced drain actions in the upgrade history", func() {
+				mockUpdater := mocks.NewMockStatusWriter(mockCtrl)
+				uc.Status.History[0].ForcedDrainActions = []upgradev1alpha1.ForcedDrainAction{
+					{Node: "test-node-0", Namespace: "app", Pod: "pod0", Strategy: "DELETE", Action: "ForceDeleted"},
+				}
+				forced := upgradev1alpha1.ForcedDrainAction{Node: "test-node-1", Namespace: "app", Pod: "pod1", Strategy: "PDB-DELETE", Action: "ForceDeleted", PodDisruptionBudget: "app/pdb"}
+				var recorded *upgradev1alpha1.UpgradeConfig
+				gomock.InOrder(
+					mockUpgradeConfigManagerBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUpgradeConfigManager, nil),
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true}, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), testNodeName, gomock.Any()).Times(1),
+					mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}}),
+					mockMetricsBuilder.EXPECT().NewClient(gomock.Any()).Return(mockMetricsClient, nil),
+					mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+					mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, config),
+					mockDrainStrategyBuilder.EXPECT().NewNodeDrainStrategy(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockDrainStrategy, nil),
+					mockDrainStrategy.EXPECT().Execute(gomock.Any()).Return([]*drain.DrainStrategyResult{
+						{Message: "Drain strategy PDB pod deletion has been executed.", HasExecuted: true, ForcedActions: []upgradev1alpha1.ForcedDrainAction{forced}},
+					}, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, uc),
+					mockKubeClient.EXPECT().Status().Return(mockUpdater),
+					mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(ctx context.Context, obj *upgradev1alpha1.UpgradeConfig, opts ...interface{}) error {
+							recorded = obj
+							return nil
+						}),
+					mockDrainStrategy.EXPECT().HasFailed(gomock.Any()).Return(false, nil),
+					mockMetricsClient.EXPECT().ResetMetricNodeDrainProtectedPods(gomock.Any()),
+					mockMetricsClient.EXPECT().ResetMetricNodeDrainStuckVolumeAttachments(gomock.Any()),
+				)
+				_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: testNodeName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(recorded).NotTo(BeNil())
+				Expect(recorded.Status.History[0].ForcedDrainActions).To(HaveLen(2))
+				Expect(recorded.Status.History[0].ForcedDrainActions[1]).To(Equal(forced))
+			})
 			It("should reset any alerts once node is not cordoned", func() {
 				gomock.InOrder(
 					mockUpgradeConfigManagerBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUpgradeConfigManager, nil),
@@ -153,6 +257,8 @@ var _ = Describe("NodeKeeperController", func() {
 					mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: false}),
 					mockMetricsBuilder.EXPECT().NewClient(gomock.Any()).Return(mockMetricsClient, nil),
 					mockMetricsClient.EXPECT().ResetMetricNodeDrainFailed(gomock.Any()).Times(1),
+					mockMetricsClient.EXPECT().ResetMetricNodeDrainProtectedPods(gomock.Any()).Times(1),
+					mockMetricsClient.EXPECT().ResetMetricNodeDrainStuckVolumeAttachments(gomock.Any()).Times(1),
 					mockMetricsClient.EXPECT().UpdateMetricNodeDrainFailed(gomock.Any()).Times(0),
 				)
 				result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: testNodeName})
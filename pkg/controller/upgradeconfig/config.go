@@ -3,15 +3,19 @@ package upgradeconfig
 import (
 	"fmt"
 	"time"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/validation"
 )
 
 type config struct {
-	UpgradeWindow upgradeWindow `yaml:"upgradeWindow"`
+	UpgradeWindow upgradeWindow               `yaml:"upgradeWindow"`
+	Validation    validation.ValidationConfig `yaml:"validation"`
 }
 
 type upgradeWindow struct {
 	TimeOut      int `yaml:"timeOut" default:"120"`
 	DelayTrigger int `yaml:"delayTrigger" default:"30"`
+	JitterSpread int `yaml:"jitterSpread"`
 }
 
 func (cfg *config) IsValid() error {
@@ -21,6 +25,15 @@ func (cfg *config) IsValid() error {
 	if cfg.UpgradeWindow.DelayTrigger < 0 {
 		return fmt.Errorf("config upgrade window delay trigger is invalid")
 	}
+	if cfg.UpgradeWindow.JitterSpread < 0 {
+		return fmt.Errorf("config upgrade window jitter spread is invalid")
+	}
+	if cfg.UpgradeWindow.TimeOut > 0 && cfg.UpgradeWindow.JitterSpread >= cfg.UpgradeWindow.TimeOut {
+		return fmt.Errorf("config upgrade window jitter spread must be shorter than the time out")
+	}
+	if err := cfg.Validation.IsValid(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -31,3 +44,7 @@ func (cfg *config) GetUpgradeWindowTimeOutDuration() time.Duration {
 func (cfg *config) GetUpgradeWindowDelayTriggerDuration() time.Duration {
 	return time.Duration(cfg.UpgradeWindow.DelayTrigger) * time.Minute
 }
+
+func (cfg *config) GetUpgradeWindowJitterSpreadDuration() time.Duration {
+	return time.Duration(cfg.UpgradeWindow.JitterSpread) * time.Minute
+}
@@ -10,9 +10,12 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/hashicorp/go-multierror"
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -29,6 +32,7 @@ import (
 	"github.com/openshift/managed-upgrade-operator/pkg/configmanager"
 	"github.com/openshift/managed-upgrade-operator/pkg/eventmanager"
 	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
 	"github.com/openshift/managed-upgrade-operator/pkg/scheduler"
 	ucmgr "github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager"
 	"github.com/openshift/managed-upgrade-operator/pkg/validation"
@@ -46,16 +50,20 @@ func Add(mgr manager.Manager) error {
 	if err != nil {
 		return err
 	}
-	return add(mgr, newReconciler(mgr, c))
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return err
+	}
+	return add(mgr, newReconciler(mgr, c, kubeClient))
 }
 
 // newReconciler returns a new reconcile.Reconciler
-func newReconciler(mgr manager.Manager, client client.Client) reconcile.Reconciler {
+func newReconciler(mgr manager.Manager, client client.Client, kubeClient kubernetes.Interface) reconcile.Reconciler {
 	return &ReconcileUpgradeConfig{
 		client:                 client,
 		scheme:                 mgr.GetScheme(),
 		metricsClientBuilder:   metrics.NewBuilder(),
-		clusterUpgraderBuilder: cub.NewBuilder(),
+		clusterUpgraderBuilder: cub.NewBuilder(kubeClient),
 		validationBuilder:      validation.NewBuilder(),
 		configManagerBuilder:   configmanager.NewBuilder(),
 		scheduler:              scheduler.NewScheduler(),
@@ -162,39 +170,119 @@ func (r *ReconcileUpgradeConfig) Reconcile(ctx context.Context, request reconcil
 			return reconcile.Result{}, err
 		}
 
+		cfm := r.configManagerBuilder.New(r.client, request.Namespace)
+		cfg := &config{}
+		err = cfm.Into(cfg)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+
 		// Build a Validator
-		validator, err := r.validationBuilder.NewClient()
+		validator, err := r.validationBuilder.NewClient(r.client, cfg.Validation, metricsClient)
 		if err != nil {
 			return reconcile.Result{}, err
 		}
 
 		// Validate UpgradeConfig instance
-		validatorResult, err := validator.IsValidUpgradeConfig(instance, clusterVersion, reqLogger)
-		if err != nil {
+		validatorResult, validationErr := validator.IsValidUpgradeConfig(instance, clusterVersion, reqLogger)
+		history.IncompatibleOperators = validatorResult.IncompatibleOperators
+		history.RemovedAPIUsage = validatorResult.RemovedAPIUsage
+		history.AdminAcks = validatorResult.AdminAcks
+		history.PendingAdminAcks = validatorResult.PendingAdminAcks
+		history.ConditionalUpdateRisks = validatorResult.ConditionalUpdateRisks
+		history.ReleaseImage = validatorResult.ReleaseImage
+		validationChanged := history.Conditions.SetCondition(newValidationCondition(validatorResult))
+		if validationErr != nil {
 			reqLogger.Info("An error occurred while validating UpgradeConfig")
-			return reconcile.Result{}, err
+			if validationChanged {
+				instance.Status.History.SetHistory(*history)
+				err = r.client.Status().Update(context.TODO(), instance)
+				if err != nil {
+					return reconcile.Result{}, err
+				}
+			}
+			return reconcile.Result{}, validationErr
 		}
 		if !validatorResult.IsValid {
 			reqLogger.Info(validatorResult.Message)
 			metricsClient.UpdateMetricValidationFailed(instance.Name)
+			// Validation failures the cluster's administrators must act on cancel the upgrade once it is due to start.
+			// Until then, the validation condition records why the upgrade cannot proceed.
+			cancelUpgrade := len(validatorResult.IncompatibleOperators) > 0 || len(validatorResult.RemovedAPIUsage) > 0 || len(validatorResult.PendingAdminAcks) > 0 || len(validatorResult.ConditionalUpdateRisks) > 0
+			if cancelUpgrade {
+				offset := getScheduleOffset(instance, clusterVersion, cfg)
+				cancelUpgrade = r.scheduler.IsReadyToUpgrade(instance, cfg.GetUpgradeWindowTimeOutDuration(), offset).IsReady
+			}
+			if validationChanged {
+				instance.Status.History.SetHistory(*history)
+				err = r.client.Status().Update(context.TODO(), instance)
+				if err != nil {
+					return reconcile.Result{}, err
+				}
+			}
+			if cancelUpgrade {
+				// The upgrade is only marked as failed once the failure is notified, so that a failed notification is retried
+				reqLogger.Info("The upgrade is cancelled as the UpgradeConfig failed validation.")
+				err = eventClient.Notify(notifier.StateFailed)
+				if err != nil {
+					return reconcile.Result{}, err
+				}
+
+				history.Phase = upgradev1alpha1.UpgradePhaseFailed
+				instance.Status.History.SetHistory(*history)
+				err = r.client.Status().Update(context.TODO(), instance)
+				if err != nil {
+					return reconcile.Result{}, err
+				}
+			}
 			return reconcile.Result{}, nil
 		}
 		metricsClient.UpdateMetricValidationSucceeded(instance.Name)
 		if !validatorResult.IsAvailableUpdate {
 			reqLogger.Info(validatorResult.Message)
+			if validationChanged {
+				instance.Status.History.SetHistory(*history)
+				err = r.client.Status().Update(context.TODO(), instance)
+				if err != nil {
+					return reconcile.Result{}, err
+				}
+			}
 			return reconcile.Result{}, nil
 		}
 		reqLogger.Info("UpgradeConfig validated and confirmed for upgrade.")
 
-		cfm := r.configManagerBuilder.New(r.client, request.Namespace)
-		cfg := &config{}
-		err = cfm.Into(cfg)
-		if err != nil {
-			return reconcile.Result{}, err
+		reqLogger.Info(fmt.Sprintf("Checking if cluster can commence %s upgrade.", instance.Spec.Type))
+		// Stagger the upgrade's start across clusters scheduled for the same time
+		history.ScheduleOffset = nil
+		offset := getScheduleOffset(instance, clusterVersion, cfg)
+		if offset > 0 {
+			history.ScheduleOffset = &metav1.Duration{Duration: offset}
 		}
+		schedulerResult := r.scheduler.IsReadyToUpgrade(instance, cfg.GetUpgradeWindowTimeOutDuration(), offset)
+		if schedulerResult.IsReady && schedulerResult.IsBreached {
+			// The upgrade window closed before the upgrade could commence, so fail without performing any step
+			reqLogger.Info("The upgrade window was breached before the upgrade commenced.")
+			if history.Conditions.SetCondition(newUpgradeWindowBreachedCondition(instance, cfg.GetUpgradeWindowTimeOutDuration())) {
+				instance.Status.History.SetHistory(*history)
+				err = r.client.Status().Update(context.TODO(), instance)
+				if err != nil {
+					return reconcile.Result{}, err
+				}
+			}
+			err = eventClient.Notify(notifier.StateFailed)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			metricsClient.UpdateMetricUpgradeWindowBreached(instance.Name)
 
-		reqLogger.Info(fmt.Sprintf("Checking if cluster can commence %s upgrade.", instance.Spec.Type))
-		schedulerResult := r.scheduler.IsReadyToUpgrade(instance, cfg.GetUpgradeWindowTimeOutDuration())
+			history.Phase = upgradev1alpha1.UpgradePhaseFailed
+			instance.Status.History.SetHistory(*history)
+			err = r.client.Status().Update(context.TODO(), instance)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{}, nil
+		}
 		if schedulerResult.IsReady {
 			ucMgr, err := r.ucMgrBuilder.NewManager(r.client)
 			if err != nil {
@@ -221,6 +309,15 @@ func (r *ReconcileUpgradeConfig) Reconcile(ctx context.Context, request reconcil
 				return reconcile.Result{}, err
 			}
 
+			// Give any administrator acknowledgements the upgrade requires, if configured to, only once it starts
+			adminAcks, err := validator.AcknowledgeAdminGates(clusterVersion, instance.Spec.Desired.Version, reqLogger)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			if adminAcks != nil {
+				history.AdminAcks = adminAcks
+			}
+
 			now := time.Now()
 			history.Phase = upgradev1alpha1.UpgradePhaseUpgrading
 			history.StartTime = &metav1.Time{Time: now}
@@ -307,6 +404,47 @@ var ManagedUpgradePredicate = predicate.Funcs{
 	},
 }
 
+// newValidationCondition describes a validation result as the UpgradeConfig's Validation condition,
+// which is only true when the UpgradeConfig is valid and the desired version is an available update
+func newValidationCondition(result validation.ValidatorResult) upgradev1alpha1.UpgradeCondition {
+	status := corev1.ConditionFalse
+	if result.IsValid && result.IsAvailableUpdate {
+		status = corev1.ConditionTrue
+	}
+	return upgradev1alpha1.UpgradeCondition{
+		Type:    upgradev1alpha1.UpgradeValidated,
+		Status:  status,
+		Reason:  string(result.Reason),
+		Message: result.Message,
+	}
+}
+
+// newUpgradeWindowBreachedCondition describes an upgrade window which closed before the upgrade commenced
+func newUpgradeWindowBreachedCondition(uc *upgradev1alpha1.UpgradeConfig, timeOut time.Duration) upgradev1alpha1.UpgradeCondition {
+	message := "The upgrade window closed before the upgrade commenced"
+	start, end, err := scheduler.GetUpgradeWindow(uc, time.Now(), timeOut)
+	if err == nil && !end.IsZero() {
+		message = fmt.Sprintf("The upgrade window from %s to %s closed before the upgrade commenced", start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+	return upgradev1alpha1.UpgradeCondition{
+		Type:    upgradev1alpha1.UpgradeWindowCheck,
+		Status:  corev1.ConditionFalse,
+		Reason:  "UpgradeWindowBreached",
+		Message: message,
+	}
+}
+
+// getScheduleOffset returns the cluster's offset from the start of its upgrade window, which staggers the
+// upgrades of clusters scheduled for the same time. The offset is bounded by the length of the window.
+func getScheduleOffset(uc *upgradev1alpha1.UpgradeConfig, cv *configv1.ClusterVersion, cfg *config) time.Duration {
+	offset := scheduler.GetJitter(string(cv.Spec.ClusterID), cfg.GetUpgradeWindowJitterSpreadDuration())
+	start, end, err := scheduler.GetUpgradeWindow(uc, time.Now(), cfg.GetUpgradeWindowTimeOutDuration())
+	if err != nil {
+		return offset
+	}
+	return scheduler.BoundOffset(offset, start, end)
+}
+
 func isManagedUpgrade(name string) bool {
 	return name == ucmgr.UPGRADECONFIG_CR_NAME
 }
@@ -0,0 +1,32 @@
+package drain
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+)
+
+// newForcedDrainActions returns a record of the action forcefully taken on each pod, for the audit of the upgrade.
+// The PodDisruptionBudget each pod matched is recorded if the strategy evaluated them. The timed strategy
+// executing the action records its own name.
+func newForcedDrainActions(node *corev1.Node, pods []corev1.Pod, action string, pdbs *pdbMatcher) []upgradev1alpha1.ForcedDrainAction {
+	now := metav1.Now()
+	actions := []upgradev1alpha1.ForcedDrainAction{}
+	for _, p := range pods {
+		a := upgradev1alpha1.ForcedDrainAction{
+			Node:      node.Name,
+			Namespace: p.Namespace,
+			Pod:       p.Name,
+			Action:    action,
+			Time:      now,
+		}
+		if pdbs != nil {
+			if pdb, ok := pdbs.getRecordedPdb(p); ok {
+				a.PodDisruptionBudget = pdb.String()
+			}
+		}
+		actions = append(actions, a)
+	}
+	return actions
+}
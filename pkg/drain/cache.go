@@ -0,0 +1,68 @@
+package drain
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	storagev1 "k8s.io/api/storage/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PodNodeNameField is the field pods are listed by to find those scheduled to a node. It is indexed by
+// IndexPodsByNodeName for cached clients, and is a supported field selector for uncached clients.
+const PodNodeNameField = "spec.nodeName"
+
+// IndexPodsByNodeName indexes pods by the name of the node they are scheduled to
+func IndexPodsByNodeName(ctx context.Context, indexer client.FieldIndexer) error {
+	return indexer.IndexField(ctx, &corev1.Pod{}, PodNodeNameField, func(o client.Object) []string {
+		p, ok := o.(*corev1.Pod)
+		if !ok || p.Spec.NodeName == "" {
+			return nil
+		}
+		return []string{p.Spec.NodeName}
+	})
+}
+
+// NewCachedClient returns a client which reads the pods, PodDisruptionBudgets, PersistentVolumeClaims and
+// VolumeAttachments evaluated by drain strategies from the given cache, and otherwise uses the given client.
+// The cache must index pods with IndexPodsByNodeName.
+func NewCachedClient(c client.Client, cache client.Reader) client.Client {
+	return &cachedClient{
+		Client: c,
+		cache:  cache,
+	}
+}
+
+// cachedClient reads the objects evaluated by drain strategies from an informer cache, so that draining many
+// nodes doesn't repeatedly list every pod and VolumeAttachment in the cluster from the API server
+type cachedClient struct {
+	client.Client
+	cache client.Reader
+}
+
+func (c *cachedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	switch obj.(type) {
+	case *corev1.PersistentVolumeClaim:
+		return c.cache.Get(ctx, key, obj)
+	}
+	return c.Client.Get(ctx, key, obj)
+}
+
+func (c *cachedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	switch list.(type) {
+	case *corev1.PodList, *policyv1beta1.PodDisruptionBudgetList, *storagev1.VolumeAttachmentList:
+		return c.cache.List(ctx, list, opts...)
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
+// listPodsOnNode lists the pods scheduled to the node
+func listPodsOnNode(c client.Client, node *corev1.Node) (*corev1.PodList, error) {
+	pods := &corev1.PodList{}
+	err := c.List(context.TODO(), pods, client.MatchingFields{PodNodeNameField: node.Name})
+	if err != nil {
+		return nil, err
+	}
+	return pods, nil
+}
@@ -0,0 +1,108 @@
+package drain
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fieldIndexer records the index functions registered with it
+type fieldIndexer struct {
+	indexes map[string]client.IndexerFunc
+}
+
+func (f *fieldIndexer) IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
+	f.indexes[field] = extractValue
+	return nil
+}
+
+var _ = Describe("Cached Client", func() {
+
+	var (
+		mockCtrl       *gomock.Controller
+		mockKubeClient *mocks.MockClient
+		mockCache      *mocks.MockClient
+		cachedClient   client.Client
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		mockCache = mocks.NewMockClient(mockCtrl)
+		cachedClient = NewCachedClient(mockKubeClient, mockCache)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When listing objects", func() {
+		It("lists pods from the cache", func() {
+			mockCache.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any())
+			err := cachedClient.List(context.TODO(), &corev1.PodList{}, client.MatchingFields{PodNodeNameField: "n1"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("lists PodDisruptionBudgets from the cache", func() {
+			mockCache.EXPECT().List(gomock.Any(), gomock.Any())
+			err := cachedClient.List(context.TODO(), &policyv1beta1.PodDisruptionBudgetList{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("lists VolumeAttachments from the cache", func() {
+			mockCache.EXPECT().List(gomock.Any(), gomock.Any())
+			err := cachedClient.List(context.TODO(), &storagev1.VolumeAttachmentList{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("lists other objects from the client", func() {
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any())
+			err := cachedClient.List(context.TODO(), &corev1.NamespaceList{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When getting objects", func() {
+		It("gets PersistentVolumeClaims from the cache", func() {
+			mockCache.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any())
+			err := cachedClient.Get(context.TODO(), types.NamespacedName{Namespace: "app", Name: "data"}, &corev1.PersistentVolumeClaim{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("gets other objects from the client", func() {
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any())
+			err := cachedClient.Get(context.TODO(), types.NamespacedName{Name: "n1"}, &corev1.Node{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When listing the pods on a node", func() {
+		It("lists pods by the node they are scheduled to", func() {
+			podList := &corev1.PodList{Items: []corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "pod1"}}}}
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), client.MatchingFields{PodNodeNameField: "n1"}).SetArg(1, *podList)
+			pods, err := listPodsOnNode(mockKubeClient, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n1"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pods.Items).To(HaveLen(1))
+		})
+	})
+
+	Context("When indexing pods", func() {
+		It("indexes pods by the node they are scheduled to", func() {
+			indexer := &fieldIndexer{indexes: map[string]client.IndexerFunc{}}
+			err := IndexPodsByNodeName(context.TODO(), indexer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(indexer.indexes).To(HaveKey(PodNodeNameField))
+
+			extract := indexer.indexes[PodNodeNameField]
+			Expect(extract(&corev1.Pod{Spec: corev1.PodSpec{NodeName: "n1"}})).To(Equal([]string{"n1"}))
+			Expect(extract(&corev1.Pod{})).To(BeEmpty())
+		})
+	})
+})
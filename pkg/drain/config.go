@@ -1,13 +1,72 @@
 package drain
 
 import (
+	"fmt"
+	"regexp"
 	"time"
 )
 
+const (
+	// evictStrategyType evicts pods through the Eviction API
+	evictStrategyType = "Evict"
+	// deleteStrategyType force deletes pods
+	deleteStrategyType = "Delete"
+	// removeFinalizersStrategyType removes the finalizers from pods
+	removeFinalizersStrategyType = "RemoveFinalizers"
+	// stuckTerminatingStrategyType force deletes pods which are already terminating
+	stuckTerminatingStrategyType = "StuckTerminating"
+	// stuckVolumeAttachmentsStrategyType reports, and optionally removes, VolumeAttachments which remain on the
+	// node after the pods using their volumes have been removed from it
+	stuckVolumeAttachmentsStrategyType = "StuckVolumeAttachments"
+
+	// pdbFilterCovered selects pods covered by a PodDisruptionBudget
+	pdbFilterCovered = "Covered"
+	// pdbFilterNotCovered selects pods not covered by a PodDisruptionBudget
+	pdbFilterNotCovered = "NotCovered"
+)
+
+// defaultStrategies is the drain strategy pipeline used if none is configured
+var defaultStrategies = []DrainStrategyConfig{
+	{Name: podEvictionName, Description: "Pod eviction", Type: evictStrategyType},
+	{Name: defaultPodDeleteName, Description: "Default pod deletion", Type: deleteStrategyType, PodFilter: PodFilter{PDB: pdbFilterNotCovered}},
+	{Name: defaultPodFinalizerRemovalName, Description: "Default pod finalizer removal", Type: removeFinalizersStrategyType, PodFilter: PodFilter{PDB: pdbFilterNotCovered}},
+	{Name: stuckTerminatingPodName, Description: "Pod stuck terminating removal", Type: stuckTerminatingStrategyType, PodFilter: PodFilter{PDB: pdbFilterNotCovered}},
+	{Name: pdbPodDeleteName, Description: "PDB pod deletion", Type: deleteStrategyType, PodFilter: PodFilter{PDB: pdbFilterCovered}},
+	{Name: pdbPodFinalizerRemovalName, Description: "PDB Pod finalizer removal", Type: removeFinalizersStrategyType, PodFilter: PodFilter{PDB: pdbFilterCovered}},
+	{Name: stuckVolumeAttachmentsName, Description: "Stuck volume attachment detection", Type: stuckVolumeAttachmentsStrategyType},
+}
+
 // NodeDrain holds timeout and expected drain time fields required for NodeDrain execution
 type NodeDrain struct {
 	Timeout               int `yaml:"timeOut"`
 	ExpectedNodeDrainTime int `yaml:"expectedNodeDrainTime" default:"8"`
+	// Timed drain strategies executed against a cordoned node. The default pipeline is used if none are given.
+	Strategies []DrainStrategyConfig `yaml:"strategies"`
+}
+
+// DrainStrategyConfig configures a timed drain strategy
+type DrainStrategyConfig struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	// One of Evict, Delete, RemoveFinalizers, StuckTerminating or StuckVolumeAttachments
+	Type string `yaml:"type"`
+	// Time in minutes after the node is cordoned before the strategy is executed. Defaults to 0 for Evict
+	// strategies, to the UpgradeConfig's PDB drain timeout for strategies selecting pods covered by a
+	// PodDisruptionBudget, and to the nodeDrain timeOut otherwise.
+	WaitDuration *int      `yaml:"waitDuration"`
+	PodFilter    PodFilter `yaml:"podFilter"`
+	// Whether a StuckVolumeAttachments strategy removes the stuck VolumeAttachments, rather than only reporting them
+	CleanUp bool `yaml:"cleanUp"`
+}
+
+// PodFilter selects the pods a drain strategy applies to. DaemonSet pods are never selected.
+type PodFilter struct {
+	// Covered or NotCovered to select pods by whether a PodDisruptionBudget covers them, any pod if unset
+	PDB string `yaml:"pdb"`
+	// Regular expressions matching the namespaces of selected pods, all namespaces if none are given
+	Namespaces []string `yaml:"namespaces"`
+	// Regular expressions matching namespaces whose pods are never selected
+	ExcludedNamespaces []string `yaml:"excludedNamespaces"`
 }
 
 // GetTimeOutDuration returns the timout field from the NodeDrain object
@@ -19,3 +78,90 @@ func (nd *NodeDrain) GetTimeOutDuration() time.Duration {
 func (nd *NodeDrain) GetExpectedDrainDuration() time.Duration {
 	return time.Duration(nd.ExpectedNodeDrainTime) * time.Minute
 }
+
+// GetStrategies returns the configured drain strategy pipeline, or the default pipeline if none is configured
+func (nd *NodeDrain) GetStrategies() []DrainStrategyConfig {
+	if len(nd.Strategies) == 0 {
+		return defaultStrategies
+	}
+	return nd.Strategies
+}
+
+// IsValid returns an error if the configured drain strategy pipeline is invalid
+func (nd *NodeDrain) IsValid() error {
+	names := map[string]bool{}
+	for _, s := range nd.Strategies {
+		if s.Name == "" {
+			return fmt.Errorf("config nodeDrain strategy name is invalid")
+		}
+		if names[s.Name] {
+			return fmt.Errorf("config nodeDrain strategy %s is duplicated", s.Name)
+		}
+		names[s.Name] = true
+		if err := s.IsValid(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsValid returns an error if the drain strategy is invalid
+func (s *DrainStrategyConfig) IsValid() error {
+	switch s.Type {
+	case evictStrategyType, deleteStrategyType, removeFinalizersStrategyType, stuckTerminatingStrategyType, stuckVolumeAttachmentsStrategyType:
+	default:
+		return fmt.Errorf("config nodeDrain strategy %s type %s is invalid", s.Name, s.Type)
+	}
+	if s.CleanUp && s.Type != stuckVolumeAttachmentsStrategyType {
+		return fmt.Errorf("config nodeDrain strategy %s cleanUp is only valid for %s strategies", s.Name, stuckVolumeAttachmentsStrategyType)
+	}
+	if s.WaitDuration != nil && *s.WaitDuration < 0 {
+		return fmt.Errorf("config nodeDrain strategy %s waitDuration is invalid", s.Name)
+	}
+	switch s.PodFilter.PDB {
+	case "", pdbFilterCovered, pdbFilterNotCovered:
+	default:
+		return fmt.Errorf("config nodeDrain strategy %s podFilter pdb %s is invalid", s.Name, s.PodFilter.PDB)
+	}
+	if _, err := compileNamespacePatterns(s.PodFilter.Namespaces); err != nil {
+		return fmt.Errorf("config nodeDrain strategy %s podFilter namespaces are invalid: %v", s.Name, err)
+	}
+	if _, err := compileNamespacePatterns(s.PodFilter.ExcludedNamespaces); err != nil {
+		return fmt.Errorf("config nodeDrain strategy %s podFilter excludedNamespaces are invalid: %v", s.Name, err)
+	}
+	return nil
+}
+
+// GetDescription returns the strategy's description, defaulting to its name
+func (s *DrainStrategyConfig) GetDescription() string {
+	if s.Description == "" {
+		return s.Name
+	}
+	return s.Description
+}
+
+// GetWaitDuration returns the time after a node is cordoned before the strategy is executed
+func (s *DrainStrategyConfig) GetWaitDuration(timeOut time.Duration, pdbTimeOut time.Duration) time.Duration {
+	switch {
+	case s.WaitDuration != nil:
+		return time.Duration(*s.WaitDuration) * time.Minute
+	case s.Type == evictStrategyType:
+		return 0
+	case s.PodFilter.PDB == pdbFilterCovered:
+		return pdbTimeOut
+	default:
+		return timeOut
+	}
+}
+
+func compileNamespacePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	var compiled []*regexp.Regexp
+	for _, p := range patterns {
+		r, err := regexp.Compile(p)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, r)
+	}
+	return compiled, nil
+}
@@ -0,0 +1,165 @@
+package drain
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/pod"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Node Drain Config", func() {
+
+	var (
+		nodeDrainConfig *NodeDrain
+	)
+
+	BeforeEach(func() {
+		waitDuration := 10
+		nodeDrainConfig = &NodeDrain{
+			Timeout: 45,
+			Strategies: []DrainStrategyConfig{
+				{
+					Name:         "EVICT",
+					Type:         evictStrategyType,
+					WaitDuration: &waitDuration,
+				},
+				{
+					Name: "DELETE",
+					Type: deleteStrategyType,
+					PodFilter: PodFilter{
+						PDB:                pdbFilterNotCovered,
+						ExcludedNamespaces: []string{"^cloud-operator$"},
+					},
+				},
+			},
+		}
+	})
+
+	Context("Validating the drain strategy pipeline", func() {
+		It("should accept a valid pipeline", func() {
+			Expect(nodeDrainConfig.IsValid()).To(Succeed())
+		})
+		It("should accept an unset pipeline", func() {
+			Expect((&NodeDrain{}).IsValid()).To(Succeed())
+		})
+		It("should reject strategies without a name", func() {
+			nodeDrainConfig.Strategies[0].Name = ""
+			Expect(nodeDrainConfig.IsValid()).NotTo(Succeed())
+		})
+		It("should reject duplicated strategy names", func() {
+			nodeDrainConfig.Strategies[1].Name = "EVICT"
+			Expect(nodeDrainConfig.IsValid()).NotTo(Succeed())
+		})
+		It("should reject unknown strategy types", func() {
+			nodeDrainConfig.Strategies[0].Type = "Drain"
+			Expect(nodeDrainConfig.IsValid()).NotTo(Succeed())
+		})
+		It("should reject negative wait durations", func() {
+			waitDuration := -1
+			nodeDrainConfig.Strategies[0].WaitDuration = &waitDuration
+			Expect(nodeDrainConfig.IsValid()).NotTo(Succeed())
+		})
+		It("should reject unknown PDB filters", func() {
+			nodeDrainConfig.Strategies[1].PodFilter.PDB = "Protected"
+			Expect(nodeDrainConfig.IsValid()).NotTo(Succeed())
+		})
+		It("should reject invalid namespace patterns", func() {
+			nodeDrainConfig.Strategies[1].PodFilter.Namespaces = []string{"("}
+			Expect(nodeDrainConfig.IsValid()).NotTo(Succeed())
+		})
+		It("should only accept cleanUp for stuck volume attachment strategies", func() {
+			nodeDrainConfig.Strategies[1].CleanUp = true
+			Expect(nodeDrainConfig.IsValid()).NotTo(Succeed())
+			nodeDrainConfig.Strategies[1].Type = stuckVolumeAttachmentsStrategyType
+			Expect(nodeDrainConfig.IsValid()).To(Succeed())
+		})
+	})
+
+	Context("Getting the drain strategy pipeline", func() {
+		It("should return the configured pipeline in order", func() {
+			strategies := nodeDrainConfig.GetStrategies()
+			Expect(strategies).To(HaveLen(2))
+			Expect(strategies[0].Name).To(Equal("EVICT"))
+			Expect(strategies[1].Name).To(Equal("DELETE"))
+		})
+		It("should return the default pipeline if none is configured", func() {
+			strategies := (&NodeDrain{}).GetStrategies()
+			Expect(strategies).To(Equal(defaultStrategies))
+			Expect(strategies[0].Type).To(Equal(evictStrategyType))
+		})
+		It("should use configured wait durations", func() {
+			Expect(nodeDrainConfig.Strategies[0].GetWaitDuration(45*time.Minute, 60*time.Minute)).To(Equal(10 * time.Minute))
+		})
+		It("should default wait durations by strategy type and PDB filter", func() {
+			Expect(defaultStrategies[0].GetWaitDuration(45*time.Minute, 60*time.Minute)).To(Equal(time.Duration(0)))
+			Expect(defaultStrategies[1].GetWaitDuration(45*time.Minute, 60*time.Minute)).To(Equal(45 * time.Minute))
+			Expect(defaultStrategies[4].GetWaitDuration(45*time.Minute, 60*time.Minute)).To(Equal(60 * time.Minute))
+		})
+		It("should default descriptions to the strategy name", func() {
+			Expect(nodeDrainConfig.Strategies[0].GetDescription()).To(Equal("EVICT"))
+		})
+	})
+
+	Context("Building configured drain strategies", func() {
+		var (
+			mockCtrl       *gomock.Controller
+			mockKubeClient *mocks.MockClient
+			node           *corev1.Node
+			podList        corev1.PodList
+		)
+
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockKubeClient = mocks.NewMockClient(mockCtrl)
+			node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n1"}}
+			podList = corev1.PodList{
+				Items: []corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "cloud-operator"},
+						Spec:       corev1.PodSpec{NodeName: "n1"},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "pod2", Namespace: "app"},
+						Spec:       corev1.PodSpec{NodeName: "n1"},
+					},
+				},
+			}
+		})
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+
+		It("should build the strategy of the configured type", func() {
+			strategy, err := newDrainStrategy(mockKubeClient, nil, newPdbMatcher(&policyv1beta1.PodDisruptionBudgetList{}), map[string]bool{}, nil, 0, nodeDrainConfig.Strategies[1])
+			Expect(err).To(BeNil())
+			Expect(strategy).To(BeAssignableToTypeOf(&podDeletionStrategy{}))
+		})
+		It("should apply the configured namespace filters", func() {
+			strategy, err := newDrainStrategy(mockKubeClient, nil, newPdbMatcher(&policyv1beta1.PodDisruptionBudgetList{}), map[string]bool{}, nil, 0, nodeDrainConfig.Strategies[1])
+			Expect(err).To(BeNil())
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, podList)
+			pods, err := strategy.(*podDeletionStrategy).GetPodList(node)
+			Expect(err).To(BeNil())
+			Expect(pods.Items).To(HaveLen(1))
+			Expect(pods.Items[0].Name).To(Equal("pod2"))
+		})
+		It("should only select pods in the configured namespaces", func() {
+			sc := nodeDrainConfig.Strategies[1]
+			sc.PodFilter.ExcludedNamespaces = nil
+			sc.PodFilter.Namespaces = []string{"^cloud-.*"}
+			strategy, err := newDrainStrategy(mockKubeClient, nil, newPdbMatcher(&policyv1beta1.PodDisruptionBudgetList{}), map[string]bool{}, nil, 0, sc)
+			Expect(err).To(BeNil())
+			filtered := pod.FilterPods(&podList, strategy.(*podDeletionStrategy).filters...)
+			Expect(filtered.Items).To(HaveLen(1))
+			Expect(filtered.Items[0].Name).To(Equal("pod1"))
+		})
+	})
+})
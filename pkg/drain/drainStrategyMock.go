@@ -48,6 +48,21 @@ func (mr *MockDrainStrategyMockRecorder) Execute(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockDrainStrategy)(nil).Execute), arg0)
 }
 
+// GetPodList mocks base method
+func (m *MockDrainStrategy) GetPodList(arg0 *v1.Node) (*v1.PodList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPodList", arg0)
+	ret0, _ := ret[0].(*v1.PodList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPodList indicates an expected call of GetPodList
+func (mr *MockDrainStrategyMockRecorder) GetPodList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPodList", reflect.TypeOf((*MockDrainStrategy)(nil).GetPodList), arg0)
+}
+
 // IsValid mocks base method
 func (m *MockDrainStrategy) IsValid(arg0 *v1.Node) (bool, error) {
 	m.ctrl.T.Helper()
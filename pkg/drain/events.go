@@ -0,0 +1,157 @@
+package drain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+)
+
+var log = logf.Log.WithName("drain-events")
+
+const (
+	// forceDeletedReason is the Event reason recorded when a pod is force deleted from a node
+	forceDeletedReason = "ForceDeleted"
+	// finalizersRemovedReason is the Event reason recorded when a pod's finalizers are removed
+	finalizersRemovedReason = "FinalizersRemoved"
+	// volumeAttachmentRemovedReason is the Event reason recorded when a stuck VolumeAttachment is removed from a node
+	volumeAttachmentRemovedReason = "VolumeAttachmentRemoved"
+
+	// eventSourceComponent is the component recorded as the source of drain Events
+	eventSourceComponent = "managed-upgrade-operator"
+	// nodeEventNamespace is the namespace Events about cluster scoped nodes are recorded in, as with the kubelet
+	nodeEventNamespace = metav1.NamespaceDefault
+)
+
+// forcedActions describes the action taken on a pod for each Event reason
+var forcedActions = map[string]string{
+	forceDeletedReason:      "was force deleted",
+	finalizersRemovedReason: "had its finalizers removed",
+}
+
+// drainEventRecorder records Kubernetes Events against the pods forced from a node, the pods' controllers and
+// the node, so that tenants can see why their pods were removed. Each Event references the UpgradeConfig
+// which the node was drained for.
+type drainEventRecorder struct {
+	client        client.Client
+	upgradeConfig corev1.ObjectReference
+}
+
+func newDrainEventRecorder(c client.Client, uc *upgradev1alpha1.UpgradeConfig) *drainEventRecorder {
+	return &drainEventRecorder{
+		client: c,
+		upgradeConfig: corev1.ObjectReference{
+			APIVersion: upgradev1alpha1.SchemeGroupVersion.String(),
+			Kind:       "UpgradeConfig",
+			Namespace:  uc.Namespace,
+			Name:       uc.Name,
+			UID:        uc.UID,
+		},
+	}
+}
+
+// recordForcedPods records an Event with the given reason against each pod, its controller and the node.
+// Events are informational, so failures to record them are logged rather than failing the drain.
+func (r *drainEventRecorder) recordForcedPods(node *corev1.Node, pods []corev1.Pod, reason string) {
+	if r == nil {
+		return
+	}
+
+	me := &multierror.Error{}
+	for _, p := range pods {
+		action := fmt.Sprintf("%s while draining node %s for UpgradeConfig %s", forcedActions[reason], node.Name, r.upgradeConfig.Name)
+		podRef := corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Namespace:  p.Namespace,
+			Name:       p.Name,
+			UID:        p.UID,
+		}
+		if err := r.record(p.Namespace, podRef, reason, fmt.Sprintf("Pod %s", action)); err != nil {
+			me = multierror.Append(me, err)
+		}
+
+		if owner := metav1.GetControllerOf(&p); owner != nil {
+			ownerRef := corev1.ObjectReference{
+				APIVersion: owner.APIVersion,
+				Kind:       owner.Kind,
+				Namespace:  p.Namespace,
+				Name:       owner.Name,
+				UID:        owner.UID,
+			}
+			if err := r.record(p.Namespace, ownerRef, reason, fmt.Sprintf("Pod %s %s", p.Name, action)); err != nil {
+				me = multierror.Append(me, err)
+			}
+		}
+
+		nodeRef := corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Node",
+			Name:       node.Name,
+			UID:        node.UID,
+		}
+		if err := r.record(nodeEventNamespace, nodeRef, reason, fmt.Sprintf("Pod %s/%s %s", p.Namespace, p.Name, action)); err != nil {
+			me = multierror.Append(me, err)
+		}
+	}
+
+	if err := me.ErrorOrNil(); err != nil {
+		log.Error(err, fmt.Sprintf("Unable to record %s events for node %s", reason, node.Name))
+	}
+}
+
+// recordRemovedVolumeAttachments records an Event against the node for each VolumeAttachment removed from it.
+// As with pods, failures to record them are logged rather than failing the drain.
+func (r *drainEventRecorder) recordRemovedVolumeAttachments(node *corev1.Node, vas []storagev1.VolumeAttachment) {
+	if r == nil {
+		return
+	}
+
+	me := &multierror.Error{}
+	nodeRef := corev1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "Node",
+		Name:       node.Name,
+		UID:        node.UID,
+	}
+	for _, va := range vas {
+		message := fmt.Sprintf("VolumeAttachment %s of PersistentVolume %s was removed while draining node %s for UpgradeConfig %s",
+			va.Name, *va.Spec.Source.PersistentVolumeName, node.Name, r.upgradeConfig.Name)
+		if err := r.record(nodeEventNamespace, nodeRef, volumeAttachmentRemovedReason, message); err != nil {
+			me = multierror.Append(me, err)
+		}
+	}
+
+	if err := me.ErrorOrNil(); err != nil {
+		log.Error(err, fmt.Sprintf("Unable to record %s events for node %s", volumeAttachmentRemovedReason, node.Name))
+	}
+}
+
+func (r *drainEventRecorder) record(namespace string, involved corev1.ObjectReference, reason string, message string) error {
+	now := metav1.Now()
+	related := r.upgradeConfig
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			// As with client-go's event recorder, the name is unique to the involved object and time
+			Name:      fmt.Sprintf("%v.%x", involved.Name, now.UnixNano()),
+			Namespace: namespace,
+		},
+		InvolvedObject: involved,
+		Related:        &related,
+		Reason:         reason,
+		Message:        message,
+		Type:           corev1.EventTypeWarning,
+		Source:         corev1.EventSource{Component: eventSourceComponent},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+	return r.client.Create(context.TODO(), event)
+}
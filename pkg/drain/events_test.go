@@ -0,0 +1,181 @@
+package drain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/pod"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Drain Events", func() {
+
+	var (
+		mockCtrl       *gomock.Controller
+		mockKubeClient *mocks.MockClient
+		recorder       *drainEventRecorder
+		node           *corev1.Node
+		controlledPod  corev1.Pod
+		standalonePod  corev1.Pod
+		events         []*corev1.Event
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		uc := &upgradev1alpha1.UpgradeConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "managed-upgrade-config", Namespace: "test-namespace"},
+		}
+		recorder = newDrainEventRecorder(mockKubeClient, uc)
+		node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n1"}}
+		isController := true
+		controlledPod = corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pod1",
+				Namespace: "app",
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "rs1", Controller: &isController},
+				},
+			},
+			Spec: corev1.PodSpec{NodeName: "n1"},
+		}
+		standalonePod = corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod2", Namespace: "app"},
+			Spec:       corev1.PodSpec{NodeName: "n1"},
+		}
+		events = []*corev1.Event{}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	recordEvents := func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+		events = append(events, obj.(*corev1.Event))
+		return nil
+	}
+
+	Context("When recording forced pods", func() {
+		It("records an Event against the pod, its controller and the node", func() {
+			mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(recordEvents).Times(3)
+			recorder.recordForcedPods(node, []corev1.Pod{controlledPod}, forceDeletedReason)
+			Expect(events).To(HaveLen(3))
+
+			Expect(events[0].Namespace).To(Equal("app"))
+			Expect(events[0].InvolvedObject.Kind).To(Equal("Pod"))
+			Expect(events[0].InvolvedObject.Name).To(Equal("pod1"))
+			Expect(events[0].Message).To(Equal("Pod was force deleted while draining node n1 for UpgradeConfig managed-upgrade-config"))
+
+			Expect(events[1].Namespace).To(Equal("app"))
+			Expect(events[1].InvolvedObject.Kind).To(Equal("ReplicaSet"))
+			Expect(events[1].InvolvedObject.Name).To(Equal("rs1"))
+			Expect(events[1].Message).To(ContainSubstring("Pod pod1 was force deleted"))
+
+			Expect(events[2].Namespace).To(Equal(nodeEventNamespace))
+			Expect(events[2].InvolvedObject.Kind).To(Equal("Node"))
+			Expect(events[2].InvolvedObject.Name).To(Equal("n1"))
+			Expect(events[2].Message).To(ContainSubstring("Pod app/pod1 was force deleted"))
+
+			for _, e := range events {
+				Expect(e.Reason).To(Equal(forceDeletedReason))
+				Expect(e.Type).To(Equal(corev1.EventTypeWarning))
+				Expect(e.Related.Kind).To(Equal("UpgradeConfig"))
+				Expect(e.Related.Name).To(Equal("managed-upgrade-config"))
+			}
+		})
+		It("records no controller Event for a pod without a controller", func() {
+			mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(recordEvents).Times(2)
+			recorder.recordForcedPods(node, []corev1.Pod{standalonePod}, finalizersRemovedReason)
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].InvolvedObject.Kind).To(Equal("Pod"))
+			Expect(events[0].Reason).To(Equal(finalizersRemovedReason))
+			Expect(events[0].Message).To(HavePrefix("Pod had its finalizers removed"))
+			Expect(events[1].InvolvedObject.Kind).To(Equal("Node"))
+		})
+		It("continues recording Events when one can't be created", func() {
+			mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error")).Times(4)
+			recorder.recordForcedPods(node, []corev1.Pod{standalonePod, standalonePod}, forceDeletedReason)
+		})
+		It("records nothing without a recorder", func() {
+			var noRecorder *drainEventRecorder
+			noRecorder.recordForcedPods(node, []corev1.Pod{controlledPod}, forceDeletedReason)
+		})
+	})
+
+	Context("When a strategy forces pods from a node", func() {
+		It("records Events for the pods force deleted", func() {
+			pds := &podDeletionStrategy{
+				client:  mockKubeClient,
+				filters: []pod.PodPredicate{isOnNode(node)},
+				events:  recorder,
+			}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, corev1.PodList{Items: []corev1.Pod{standalonePod}}),
+				mockKubeClient.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()),
+				mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(recordEvents).Times(2),
+			)
+			result, err := pds.Execute(node)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.HasExecuted).To(BeTrue())
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].Reason).To(Equal(forceDeletedReason))
+			Expect(result.ForcedActions).To(HaveLen(1))
+			Expect(result.ForcedActions[0].Node).To(Equal("n1"))
+			Expect(result.ForcedActions[0].Namespace).To(Equal("app"))
+			Expect(result.ForcedActions[0].Pod).To(Equal("pod2"))
+			Expect(result.ForcedActions[0].Action).To(Equal(forceDeletedReason))
+			Expect(result.ForcedActions[0].PodDisruptionBudget).To(BeEmpty())
+		})
+		It("records no Events for pods which could not be deleted", func() {
+			pds := &podDeletionStrategy{
+				client:  mockKubeClient,
+				filters: []pod.PodPredicate{isOnNode(node)},
+				events:  recorder,
+			}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, corev1.PodList{Items: []corev1.Pod{standalonePod}}),
+				mockKubeClient.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error")),
+			)
+			_, err := pds.Execute(node)
+			Expect(err).To(HaveOccurred())
+			Expect(events).To(BeEmpty())
+		})
+	})
+
+	Context("When auditing forced pods", func() {
+		It("records the PodDisruptionBudget each pod matched", func() {
+			pdbs := newPdbMatcher(&policyv1beta1.PodDisruptionBudgetList{
+				Items: []policyv1beta1.PodDisruptionBudget{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "pdb1", Namespace: "app"},
+						Spec: policyv1beta1.PodDisruptionBudgetSpec{
+							Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "one"}},
+						},
+					},
+				},
+			})
+			controlledPod.Labels = map[string]string{"app": "one"}
+			Expect(isPdbPod(pdbs)(controlledPod)).To(BeTrue())
+			Expect(isPdbPod(pdbs)(standalonePod)).To(BeFalse())
+
+			actions := newForcedDrainActions(node, []corev1.Pod{controlledPod, standalonePod}, finalizersRemovedReason, pdbs)
+			Expect(actions).To(HaveLen(2))
+			Expect(actions[0].Pod).To(Equal("pod1"))
+			Expect(actions[0].Action).To(Equal(finalizersRemovedReason))
+			Expect(actions[0].PodDisruptionBudget).To(Equal("app/pdb1"))
+			Expect(actions[0].Time.IsZero()).To(BeFalse())
+			Expect(actions[1].Pod).To(Equal("pod2"))
+			Expect(actions[1].PodDisruptionBudget).To(BeEmpty())
+		})
+	})
+})
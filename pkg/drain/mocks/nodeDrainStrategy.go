@@ -8,6 +8,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	drain "github.com/openshift/managed-upgrade-operator/pkg/drain"
 	v1 "k8s.io/api/core/v1"
+	v10 "k8s.io/api/storage/v1"
 	reflect "reflect"
 )
 
@@ -49,6 +50,51 @@ func (mr *MockNodeDrainStrategyMockRecorder) Execute(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockNodeDrainStrategy)(nil).Execute), arg0)
 }
 
+// GetPlan mocks base method
+func (m *MockNodeDrainStrategy) GetPlan(arg0 *v1.Node) (*drain.NodeDrainPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlan", arg0)
+	ret0, _ := ret[0].(*drain.NodeDrainPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlan indicates an expected call of GetPlan
+func (mr *MockNodeDrainStrategyMockRecorder) GetPlan(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlan", reflect.TypeOf((*MockNodeDrainStrategy)(nil).GetPlan), arg0)
+}
+
+// GetProtectedPods mocks base method
+func (m *MockNodeDrainStrategy) GetProtectedPods(arg0 *v1.Node) (*v1.PodList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProtectedPods", arg0)
+	ret0, _ := ret[0].(*v1.PodList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProtectedPods indicates an expected call of GetProtectedPods
+func (mr *MockNodeDrainStrategyMockRecorder) GetProtectedPods(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProtectedPods", reflect.TypeOf((*MockNodeDrainStrategy)(nil).GetProtectedPods), arg0)
+}
+
+// GetStuckVolumeAttachments mocks base method
+func (m *MockNodeDrainStrategy) GetStuckVolumeAttachments(arg0 *v1.Node) ([]v10.VolumeAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStuckVolumeAttachments", arg0)
+	ret0, _ := ret[0].([]v10.VolumeAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStuckVolumeAttachments indicates an expected call of GetStuckVolumeAttachments
+func (mr *MockNodeDrainStrategyMockRecorder) GetStuckVolumeAttachments(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStuckVolumeAttachments", reflect.TypeOf((*MockNodeDrainStrategy)(nil).GetStuckVolumeAttachments), arg0)
+}
+
 // HasFailed mocks base method
 func (m *MockNodeDrainStrategy) HasFailed(arg0 *v1.Node) (bool, error) {
 	m.ctrl.T.Helper()
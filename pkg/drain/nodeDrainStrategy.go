@@ -7,18 +7,22 @@ import (
 
 	"github.com/hashicorp/go-multierror"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
+	"github.com/openshift/managed-upgrade-operator/pkg/pod"
 )
 
 var (
+	podEvictionName                = "EVICT"
 	defaultPodDeleteName           = "DELETE"
 	pdbPodDeleteName               = "PDB-DELETE"
 	defaultPodFinalizerRemovalName = "DEFAULT-FINALIZER"
 	pdbPodFinalizerRemovalName     = "PDB-FINALIZER"
 	stuckTerminatingPodName        = "POD-STUCK-TERMINATING"
+	stuckVolumeAttachmentsName     = "STUCK-VOLUME-ATTACHMENTS"
 )
 
 // NewNodeDrainStrategy returns a new node drain stategy
@@ -43,13 +47,19 @@ func (ds *osdDrainStrategy) Execute(node *corev1.Node) ([]*DrainStrategyResult,
 	me := &multierror.Error{}
 	res := []*DrainStrategyResult{}
 	if result.IsCordoned {
-		me := &multierror.Error{}
 		for _, ds := range ds.timedDrainStrategies {
 			if isAfter(result.AddedAt, ds.GetWaitDuration()) {
 				r, err := ds.GetStrategy().Execute(node)
-				me = multierror.Append(err, me)
-				if r.HasExecuted {
-					res = append(res, &DrainStrategyResult{Message: fmt.Sprintf("Drain strategy %s has been executed. %s", ds.GetDescription(), r.Message)})
+				me = multierror.Append(me, err)
+				if r != nil && r.HasExecuted {
+					for i := range r.ForcedActions {
+						r.ForcedActions[i].Strategy = ds.GetName()
+					}
+					res = append(res, &DrainStrategyResult{
+						Message:       fmt.Sprintf("Drain strategy %s has been executed. %s", ds.GetDescription(), r.Message),
+						HasExecuted:   true,
+						ForcedActions: r.ForcedActions,
+					})
 				}
 			}
 		}
@@ -68,6 +78,16 @@ func (ds *osdDrainStrategy) HasFailed(node *corev1.Node) (bool, error) {
 		return isAfter(result.AddedAt, ds.cfg.GetTimeOutDuration()), nil
 	}
 
+	// No pod drain strategy can detach a volume, so the drain has failed once a volume is stuck attached,
+	// regardless of any strategies still pending
+	stuckAttachments, err := ds.getStuckVolumeAttachments(node, result.AddedAt)
+	if err != nil {
+		return false, err
+	}
+	if len(stuckAttachments) > 0 {
+		return true, nil
+	}
+
 	sortedStrategies := sortDuration(ds.timedDrainStrategies)
 	var executedStrategies []TimedDrainStrategy
 	currentStrategyIndex := 0
@@ -104,6 +124,54 @@ func (ds *osdDrainStrategy) HasFailed(node *corev1.Node) (bool, error) {
 	return isAfter(result.AddedAt, ds.cfg.GetTimeOutDuration()), nil
 }
 
+// GetProtectedPods returns the drain protected pods remaining on the node, which no strategy will force from it
+func (ds *osdDrainStrategy) GetProtectedPods(node *corev1.Node) (*corev1.PodList, error) {
+	protectedNamespaces, err := getDrainProtectedNamespaces(ds.client)
+	if err != nil {
+		return nil, err
+	}
+
+	allPods, err := listPodsOnNode(ds.client, node)
+	if err != nil {
+		return nil, err
+	}
+
+	return pod.FilterPods(allPods, isOnNode(node), isNotDaemonSet, isDrainProtected(protectedNamespaces)), nil
+}
+
+// GetStuckVolumeAttachments returns the VolumeAttachments stuck on the node, as detected by the timed strategies
+// which have had time to detect, and optionally remove, them
+func (ds *osdDrainStrategy) GetStuckVolumeAttachments(node *corev1.Node) ([]storagev1.VolumeAttachment, error) {
+	result := ds.machinery.IsNodeCordoned(node)
+	return ds.getStuckVolumeAttachments(node, result.AddedAt)
+}
+
+// getStuckVolumeAttachments returns the VolumeAttachments detected by volume attachment strategies whose wait
+// duration, plus the expected drain duration for any removal to complete, has passed since the node was cordoned.
+// Attachments must also have been stuck for the expected drain duration, so that those released by pods late in
+// the drain have the same chance to be removed as the rest.
+func (ds *osdDrainStrategy) getStuckVolumeAttachments(node *corev1.Node, cordonedAt *metav1.Time) ([]storagev1.VolumeAttachment, error) {
+	var stuck []storagev1.VolumeAttachment
+	seen := map[string]bool{}
+	for _, tds := range ds.timedDrainStrategies {
+		vas, ok := tds.GetStrategy().(volumeAttachmentStrategy)
+		if !ok || !isAfter(cordonedAt, tds.GetWaitDuration()+ds.cfg.GetExpectedDrainDuration()) {
+			continue
+		}
+		attachments, err := vas.GetStuckVolumeAttachments(node, ds.cfg.GetExpectedDrainDuration())
+		if err != nil {
+			return nil, err
+		}
+		for _, va := range attachments {
+			if !seen[va.Name] {
+				seen[va.Name] = true
+				stuck = append(stuck, va)
+			}
+		}
+	}
+	return stuck, nil
+}
+
 type timedStrategy struct {
 	name         string
 	description  string
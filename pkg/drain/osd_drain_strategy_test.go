@@ -1,13 +1,16 @@
 package drain
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
 	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
 	mockMachinery "github.com/openshift/managed-upgrade-operator/pkg/machinery/mocks"
 	"github.com/openshift/managed-upgrade-operator/pkg/pod"
@@ -80,6 +83,53 @@ var _ = Describe("OSD Drain Strategy", func() {
 			Expect(err).To(BeNil())
 			Expect(len(result)).To(Equal(1))
 		})
+		It("should record the executing strategy's name against the actions it forced", func() {
+			osdDrain = &osdDrainStrategy{
+				mockKubeClient,
+				mockMachineryClient,
+				&NodeDrain{},
+				[]TimedDrainStrategy{mockTimedDrainOne},
+			}
+			fortyFiveMinsAgo := &metav1.Time{Time: time.Now().Add(-45 * time.Minute)}
+			forced := []upgradev1alpha1.ForcedDrainAction{{Node: "n1", Namespace: "app", Pod: "pod1", Action: forceDeletedReason}}
+			gomock.InOrder(
+				mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: fortyFiveMinsAgo}),
+				mockTimedDrainOne.EXPECT().GetWaitDuration().Return(time.Minute*30),
+				mockTimedDrainOne.EXPECT().GetStrategy().Return(mockStrategyOne),
+				mockStrategyOne.EXPECT().Execute(gomock.Any()).Times(1).Return(&DrainStrategyResult{Message: "", HasExecuted: true, ForcedActions: forced}, nil),
+				mockTimedDrainOne.EXPECT().GetName().Return("DELETE"),
+				mockTimedDrainOne.EXPECT().GetDescription().Times(1).Return("Drain one"),
+			)
+			result, err := osdDrain.Execute(&corev1.Node{})
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].ForcedActions).To(HaveLen(1))
+			Expect(result[0].ForcedActions[0].Strategy).To(Equal("DELETE"))
+			Expect(result[0].ForcedActions[0].Pod).To(Equal("pod1"))
+		})
+		It("should return the errors of strategies which fail without a result", func() {
+			osdDrain = &osdDrainStrategy{
+				mockKubeClient,
+				mockMachineryClient,
+				&NodeDrain{},
+				[]TimedDrainStrategy{mockTimedDrainOne, mockTimedDrainTwo},
+			}
+			fortyFiveMinsAgo := &metav1.Time{Time: time.Now().Add(-45 * time.Minute)}
+			gomock.InOrder(
+				mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: fortyFiveMinsAgo}),
+				mockTimedDrainOne.EXPECT().GetWaitDuration().Return(time.Minute*30),
+				mockTimedDrainOne.EXPECT().GetStrategy().Return(mockStrategyOne),
+				mockStrategyOne.EXPECT().Execute(gomock.Any()).Times(1).Return(nil, fmt.Errorf("fake error")),
+				mockTimedDrainTwo.EXPECT().GetWaitDuration().Return(time.Minute*30),
+				mockTimedDrainTwo.EXPECT().GetStrategy().Return(mockStrategyTwo),
+				mockStrategyTwo.EXPECT().Execute(gomock.Any()).Times(1).Return(&DrainStrategyResult{Message: "", HasExecuted: true}, nil),
+				mockTimedDrainTwo.EXPECT().GetDescription().Times(1).Return("Drain two"),
+			)
+			result, err := osdDrain.Execute(&corev1.Node{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake error"))
+			Expect(result).To(HaveLen(1))
+		})
 		It("should not execute a Time Based Drain Strategy before the assigned duration", func() {
 			osdDrain = &osdDrainStrategy{
 				mockKubeClient,
@@ -166,7 +216,9 @@ var _ = Describe("OSD Drain Strategy", func() {
 				mockCtrl = gomock.NewController(GinkgoT())
 				mockKubeClient = mocks.NewMockClient(mockCtrl)
 				mockTimedDrainOne = NewMockTimedDrainStrategy(mockCtrl)
+				mockStrategyOne = NewMockDrainStrategy(mockCtrl)
 				mockTimedDrainTwo = NewMockTimedDrainStrategy(mockCtrl)
+				mockStrategyTwo = NewMockDrainStrategy(mockCtrl)
 				nodeDrainConfig = &NodeDrain{
 					ExpectedNodeDrainTime: 8,
 					Timeout:               15,
@@ -177,6 +229,9 @@ var _ = Describe("OSD Drain Strategy", func() {
 					nodeDrainConfig,
 					[]TimedDrainStrategy{mockTimedDrainTwo, mockTimedDrainOne},
 				}
+				// Neither strategy detects stuck volume attachments
+				mockTimedDrainTwo.EXPECT().GetStrategy().Return(mockStrategyTwo)
+				mockTimedDrainOne.EXPECT().GetStrategy().Return(mockStrategyOne)
 			})
 			AfterEach(func() {
 				mockCtrl.Finish()
@@ -238,6 +293,153 @@ var _ = Describe("OSD Drain Strategy", func() {
 		})
 	})
 
+	Context("Stuck volume attachments blocking a node", func() {
+		var (
+			node             *corev1.Node
+			volumeStrategy   TimedDrainStrategy
+			tracker          *volumeAttachmentTracker
+			pvName           string
+			attachmentList   storagev1.VolumeAttachmentList
+			tooLongAgo       *metav1.Time
+			strategyDuration time.Duration
+		)
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockKubeClient = mocks.NewMockClient(mockCtrl)
+			mockMachineryClient = mockMachinery.NewMockMachinery(mockCtrl)
+			mockTimedDrainOne = NewMockTimedDrainStrategy(mockCtrl)
+			mockStrategyOne = NewMockDrainStrategy(mockCtrl)
+			node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n1"}}
+			strategyDuration = 30 * time.Minute
+			tracker = newVolumeAttachmentTracker()
+			volumeStrategy = newTimedStrategy(stuckVolumeAttachmentsName, "Stuck volume attachment detection", strategyDuration, &stuckVolumeAttachmentsStrategy{client: mockKubeClient, tracker: tracker})
+			nodeDrainConfig = &NodeDrain{
+				ExpectedNodeDrainTime: 8,
+				Timeout:               45,
+			}
+			osdDrain = &osdDrainStrategy{
+				mockKubeClient,
+				mockMachineryClient,
+				nodeDrainConfig,
+				[]TimedDrainStrategy{volumeStrategy, mockTimedDrainOne},
+			}
+			pvName = "pv-0"
+			attachmentList = storagev1.VolumeAttachmentList{
+				Items: []storagev1.VolumeAttachment{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "csi-0", UID: "csi-0-uid"},
+						Spec: storagev1.VolumeAttachmentSpec{
+							NodeName: "n1",
+							Source:   storagev1.VolumeAttachmentSource{PersistentVolumeName: &pvName},
+						},
+					},
+				},
+			}
+			tooLongAgo = &metav1.Time{Time: time.Now().Add(-strategyDuration - nodeDrainConfig.GetExpectedDrainDuration() - time.Minute)}
+			tracker.track("n1", attachmentList.Items, tooLongAgo.Time)
+		})
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+		It("should fail if volume attachments are stuck, regardless of pending strategies", func() {
+			gomock.InOrder(
+				mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: tooLongAgo}),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, attachmentList),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, corev1.PodList{}),
+				mockTimedDrainOne.EXPECT().GetStrategy().Return(mockStrategyOne),
+			)
+			result, err := osdDrain.HasFailed(node)
+			Expect(err).To(BeNil())
+			Expect(result).To(BeTrue())
+		})
+		It("should not look for stuck volume attachments before the strategy and expected drain time have elapsed", func() {
+			notLongEnough := &metav1.Time{Time: time.Now().Add(-strategyDuration)}
+			mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: notLongEnough})
+			mockTimedDrainOne.EXPECT().GetStrategy().Return(mockStrategyOne)
+			stuck, err := osdDrain.GetStuckVolumeAttachments(node)
+			Expect(err).To(BeNil())
+			Expect(stuck).To(BeEmpty())
+		})
+		It("should not consider volume attachments stuck until unused for the expected drain time", func() {
+			tracker.track("n1", nil, time.Now())
+			tracker.track("n1", attachmentList.Items, time.Now().Add(-time.Minute))
+			gomock.InOrder(
+				mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: tooLongAgo}),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, attachmentList),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, corev1.PodList{}),
+				mockTimedDrainOne.EXPECT().GetStrategy().Return(mockStrategyOne),
+			)
+			stuck, err := osdDrain.GetStuckVolumeAttachments(node)
+			Expect(err).To(BeNil())
+			Expect(stuck).To(BeEmpty())
+		})
+		It("should return the stuck volume attachments on the node", func() {
+			gomock.InOrder(
+				mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: tooLongAgo}),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, attachmentList),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, corev1.PodList{}),
+				mockTimedDrainOne.EXPECT().GetStrategy().Return(mockStrategyOne),
+			)
+			stuck, err := osdDrain.GetStuckVolumeAttachments(node)
+			Expect(err).To(BeNil())
+			Expect(stuck).To(HaveLen(1))
+			Expect(stuck[0].Name).To(Equal("csi-0"))
+		})
+	})
+
+	Context("Drain protected pods blocking a node", func() {
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockKubeClient = mocks.NewMockClient(mockCtrl)
+			mockMachineryClient = mockMachinery.NewMockMachinery(mockCtrl)
+		})
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+		It("should return the drain protected pods on the node", func() {
+			osdDrain = &osdDrainStrategy{
+				mockKubeClient,
+				mockMachineryClient,
+				&NodeDrain{},
+				[]TimedDrainStrategy{},
+			}
+			nsList := corev1.NamespaceList{
+				Items: []corev1.Namespace{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        "database",
+							Annotations: map[string]string{drainProtectedAnnotation: drainProtectedValue},
+						},
+					},
+				},
+			}
+			podList := corev1.PodList{
+				Items: []corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "stateful-0", Namespace: "database"},
+						Spec:       corev1.PodSpec{NodeName: "n1"},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "stateful-1", Namespace: "database"},
+						Spec:       corev1.PodSpec{NodeName: "n2"},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "app"},
+						Spec:       corev1.PodSpec{NodeName: "n1"},
+					},
+				},
+			}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, nsList),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, podList),
+			)
+			protectedPods, err := osdDrain.GetProtectedPods(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n1"}})
+			Expect(err).To(BeNil())
+			Expect(protectedPods.Items).To(HaveLen(1))
+			Expect(protectedPods.Items[0].Name).To(Equal("stateful-0"))
+		})
+	})
+
 	Context("Pod Predicates", func() {
 		var (
 			podList *corev1.PodList
@@ -304,16 +506,51 @@ var _ = Describe("OSD Drain Strategy", func() {
 				}
 			})
 			It("should return pods that have an associated PodDisruptionBudget", func() {
-				filteredPods := pod.FilterPods(podList, isPdbPod(pdbList))
+				filteredPods := pod.FilterPods(podList, isPdbPod(newPdbMatcher(pdbList)))
 				Expect(len(filteredPods.Items)).To(Equal(1))
 				Expect(filteredPods.Items[0].Name).To(Equal(pdbPodName))
 			})
 			It("should return pods that do not have an associated PodDisruptionBudget", func() {
-				filteredPods := pod.FilterPods(podList, isNotPdbPod(pdbList))
+				filteredPods := pod.FilterPods(podList, isNotPdbPod(newPdbMatcher(pdbList)))
 				Expect(len(filteredPods.Items)).To(Equal(2))
 				Expect(filteredPods.Items[0].Name).To(Not(Equal(pdbPodName)))
 				Expect(filteredPods.Items[1].Name).To(Not(Equal(pdbPodName)))
 			})
+			It("should only match pods which match every label of a selector", func() {
+				pdbList.Items[0].Spec.Selector.MatchLabels["other-label"] = "label2"
+				filteredPods := pod.FilterPods(podList, isPdbPod(newPdbMatcher(pdbList)))
+				Expect(filteredPods.Items).To(BeEmpty())
+			})
+			It("should evaluate selector match expressions", func() {
+				pdbList.Items[1].Spec.Selector = &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "app", Operator: metav1.LabelSelectorOpIn, Values: []string{"app2", "app3"}},
+					},
+				}
+				filteredPods := pod.FilterPods(podList, isPdbPod(newPdbMatcher(pdbList)))
+				Expect(len(filteredPods.Items)).To(Equal(3))
+			})
+			It("should not match pods with a PodDisruptionBudget that has an empty selector", func() {
+				pdbList.Items[0].Spec.Selector = &metav1.LabelSelector{}
+				filteredPods := pod.FilterPods(podList, isPdbPod(newPdbMatcher(pdbList)))
+				Expect(filteredPods.Items).To(BeEmpty())
+			})
+			It("should only match pods in the PodDisruptionBudget's namespace", func() {
+				pdbList.Items[0].Namespace = "other-namespace"
+				filteredPods := pod.FilterPods(podList, isPdbPod(newPdbMatcher(pdbList)))
+				Expect(filteredPods.Items).To(BeEmpty())
+			})
+			It("should record the PodDisruptionBudget each pod matched", func() {
+				pdbList.Items[0].Name = "test-pdb"
+				matcher := newPdbMatcher(pdbList)
+				_ = pod.FilterPods(podList, isPdbPod(matcher))
+				matched, ok := matcher.getRecordedPdb(podList.Items[0])
+				Expect(ok).To(BeTrue())
+				Expect(matched.Name).To(Equal("test-pdb"))
+				_, ok = matcher.getRecordedPdb(podList.Items[1])
+				Expect(ok).To(BeFalse())
+				Expect(matcher.describeRecordedPdbs(podList)).To(ContainSubstring(pdbPodName + " (/test-pdb)"))
+			})
 		})
 
 		Context("Pods on a Node", func() {
@@ -444,6 +681,71 @@ var _ = Describe("OSD Drain Strategy", func() {
 				Expect(len(filteredPods.Items)).To(Equal(0))
 			})
 		})
+		Context("Drain protected pods", func() {
+			BeforeEach(func() {
+				podList = &corev1.PodList{
+					Items: []corev1.Pod{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:        "protected-pod",
+								Namespace:   "app",
+								Annotations: map[string]string{drainProtectedAnnotation: drainProtectedValue},
+							},
+						},
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "protected-namespace-pod",
+								Namespace: "database",
+							},
+						},
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:        "unprotected-pod",
+								Namespace:   "app",
+								Annotations: map[string]string{drainProtectedAnnotation: "false"},
+							},
+						},
+					},
+				}
+			})
+			It("should return pods protected by their own or their namespace's annotation", func() {
+				filteredPods := pod.FilterPods(podList, isDrainProtected(map[string]bool{"database": true}))
+				Expect(len(filteredPods.Items)).To(Equal(2))
+				Expect(filteredPods.Items[0].Name).To(Equal("protected-pod"))
+				Expect(filteredPods.Items[1].Name).To(Equal("protected-namespace-pod"))
+			})
+			It("should not return protected pods when filtering unprotected pods", func() {
+				filteredPods := pod.FilterPods(podList, isNotDrainProtected(map[string]bool{"database": true}))
+				Expect(len(filteredPods.Items)).To(Equal(1))
+				Expect(filteredPods.Items[0].Name).To(Equal("unprotected-pod"))
+			})
+			It("should only exclude protected pods from strategies which force pods from a node", func() {
+				pdbs := newPdbMatcher(&policyv1beta1.PodDisruptionBudgetList{})
+				protectedNamespaces := map[string]bool{"database": true}
+				for _, strategyType := range []string{evictStrategyType, deleteStrategyType, removeFinalizersStrategyType, stuckTerminatingStrategyType} {
+					sc := DrainStrategyConfig{Name: strategyType, Type: strategyType}
+					strategy, err := newDrainStrategy(nil, nil, pdbs, protectedNamespaces, nil, 0, sc)
+					Expect(err).To(BeNil())
+					var filters []pod.PodPredicate
+					switch s := strategy.(type) {
+					case *podEvictionStrategy:
+						filters = s.filters
+					case *podDeletionStrategy:
+						filters = s.filters
+					case *removeFinalizersStrategy:
+						filters = s.filters
+					case *stuckTerminatingStrategy:
+						filters = s.filters
+					}
+					filteredPods := pod.FilterPods(podList, filters...)
+					if sc.Type == evictStrategyType {
+						Expect(len(filteredPods.Items)).To(Equal(3))
+					} else {
+						Expect(len(filteredPods.Items)).To(Equal(1))
+					}
+				}
+			})
+		})
 
 	})
 })
@@ -0,0 +1,139 @@
+package drain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// pdbMatcher evaluates PodDisruptionBudget selectors against pods, recording the
+// PodDisruptionBudget which each evaluated pod matched
+type pdbMatcher struct {
+	pdbs    []pdbSelector
+	matches map[types.NamespacedName]types.NamespacedName
+}
+
+type pdbSelector struct {
+	name     types.NamespacedName
+	selector labels.Selector
+}
+
+func newPdbMatcher(pdbList *policyv1beta1.PodDisruptionBudgetList) *pdbMatcher {
+	m := &pdbMatcher{
+		matches: map[types.NamespacedName]types.NamespacedName{},
+	}
+	for _, pdb := range pdbList.Items {
+		// As with the disruption controller, a policy/v1beta1 PDB with an empty selector matches no pods
+		if pdb.Spec.Selector == nil || (len(pdb.Spec.Selector.MatchLabels) == 0 && len(pdb.Spec.Selector.MatchExpressions) == 0) {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			// An invalid selector can't protect any pod from eviction either
+			continue
+		}
+		m.pdbs = append(m.pdbs, pdbSelector{
+			name:     types.NamespacedName{Namespace: pdb.Namespace, Name: pdb.Name},
+			selector: selector,
+		})
+	}
+	return m
+}
+
+// getMatchingPdb returns the PodDisruptionBudget in the pod's namespace whose selector matches the pod,
+// recording the match against the pod
+func (m *pdbMatcher) getMatchingPdb(p corev1.Pod) (types.NamespacedName, bool) {
+	podName := types.NamespacedName{Namespace: p.Namespace, Name: p.Name}
+	podLabels := labels.Set(p.Labels)
+	for _, pdb := range m.pdbs {
+		if pdb.name.Namespace == p.Namespace && pdb.selector.Matches(podLabels) {
+			m.matches[podName] = pdb.name
+			return pdb.name, true
+		}
+	}
+	delete(m.matches, podName)
+	return types.NamespacedName{}, false
+}
+
+// getRecordedPdb returns the PodDisruptionBudget which the pod matched when last evaluated
+func (m *pdbMatcher) getRecordedPdb(p corev1.Pod) (types.NamespacedName, bool) {
+	pdb, ok := m.matches[types.NamespacedName{Namespace: p.Namespace, Name: p.Name}]
+	return pdb, ok
+}
+
+// describeRecordedPdbs describes the PodDisruptionBudget recorded against each pod in the list which matched one
+func (m *pdbMatcher) describeRecordedPdbs(pl *corev1.PodList) string {
+	var matched []string
+	for _, p := range pl.Items {
+		if pdb, ok := m.getRecordedPdb(p); ok {
+			matched = append(matched, fmt.Sprintf("%s (%s)", p.Name, pdb))
+		}
+	}
+	if len(matched) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Pod(s) covered by a PodDisruptionBudget: %s", strings.Join(matched, ","))
+}
+
+// GetBlockingPdbs returns the namespaced names of the PodDisruptionBudgets which cover pods but allow none of them
+// to be disrupted, and so will block node drains until they are forcefully overridden. PodDisruptionBudgets in
+// namespaces matching any of the excluded namespace patterns are not returned.
+func GetBlockingPdbs(c client.Client, excludedNamespaces []string) ([]string, error) {
+	excluded, err := compileNamespacePatterns(excludedNamespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	pdbList := &policyv1beta1.PodDisruptionBudgetList{}
+	err = c.List(context.TODO(), pdbList)
+	if err != nil {
+		return nil, err
+	}
+
+	blocking := []string{}
+	for _, pdb := range pdbList.Items {
+		if !isBlockingPdb(pdb) {
+			continue
+		}
+		isExcluded := false
+		for _, r := range excluded {
+			if r.MatchString(pdb.Namespace) {
+				isExcluded = true
+				break
+			}
+		}
+		if !isExcluded {
+			blocking = append(blocking, types.NamespacedName{Namespace: pdb.Namespace, Name: pdb.Name}.String())
+		}
+	}
+	return blocking, nil
+}
+
+// isBlockingPdb returns true if the PodDisruptionBudget covers pods but currently allows no disruptions,
+// or is specified such that it never will
+func isBlockingPdb(pdb policyv1beta1.PodDisruptionBudget) bool {
+	expectedPods := int(pdb.Status.ExpectedPods)
+	if expectedPods == 0 {
+		return false
+	}
+	if pdb.Status.DisruptionsAllowed == 0 {
+		return true
+	}
+	if pdb.Spec.MaxUnavailable != nil {
+		maxUnavailable, err := intstr.GetValueFromIntOrPercent(pdb.Spec.MaxUnavailable, expectedPods, true)
+		return err == nil && maxUnavailable == 0
+	}
+	if pdb.Spec.MinAvailable != nil {
+		minAvailable, err := intstr.GetValueFromIntOrPercent(pdb.Spec.MinAvailable, expectedPods, true)
+		return err == nil && minAvailable >= expectedPods
+	}
+	return false
+}
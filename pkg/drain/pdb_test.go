@@ -0,0 +1,106 @@
+package drain
+
+import (
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Blocking PodDisruptionBudgets", func() {
+
+	var (
+		mockCtrl       *gomock.Controller
+		mockKubeClient *mocks.MockClient
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	newPdb := func(namespace string, name string, expectedPods int32, disruptionsAllowed int32) policyv1beta1.PodDisruptionBudget {
+		return policyv1beta1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Status: policyv1beta1.PodDisruptionBudgetStatus{
+				ExpectedPods:       expectedPods,
+				DisruptionsAllowed: disruptionsAllowed,
+			},
+		}
+	}
+
+	Context("When assessing whether a PodDisruptionBudget blocks drains", func() {
+		It("does not block if it covers no pods", func() {
+			Expect(isBlockingPdb(newPdb("ns", "pdb", 0, 0))).To(BeFalse())
+		})
+		It("blocks if it allows no disruptions", func() {
+			Expect(isBlockingPdb(newPdb("ns", "pdb", 2, 0))).To(BeTrue())
+		})
+		It("does not block if it allows disruptions", func() {
+			Expect(isBlockingPdb(newPdb("ns", "pdb", 2, 1))).To(BeFalse())
+		})
+		It("blocks if no pods may be unavailable", func() {
+			pdb := newPdb("ns", "pdb", 2, 1)
+			maxUnavailable := intstr.FromString("0%")
+			pdb.Spec.MaxUnavailable = &maxUnavailable
+			Expect(isBlockingPdb(pdb)).To(BeTrue())
+		})
+		It("blocks if every pod must be available", func() {
+			pdb := newPdb("ns", "pdb", 3, 1)
+			minAvailable := intstr.FromString("100%")
+			pdb.Spec.MinAvailable = &minAvailable
+			Expect(isBlockingPdb(pdb)).To(BeTrue())
+		})
+		It("does not block if some pods may be unavailable", func() {
+			pdb := newPdb("ns", "pdb", 3, 1)
+			minAvailable := intstr.FromInt(2)
+			pdb.Spec.MinAvailable = &minAvailable
+			Expect(isBlockingPdb(pdb)).To(BeFalse())
+		})
+	})
+
+	Context("When listing blocking PodDisruptionBudgets", func() {
+		var pdbList *policyv1beta1.PodDisruptionBudgetList
+		BeforeEach(func() {
+			pdbList = &policyv1beta1.PodDisruptionBudgetList{
+				Items: []policyv1beta1.PodDisruptionBudget{
+					newPdb("app", "blocking", 1, 0),
+					newPdb("app", "allowing", 2, 1),
+					newPdb("openshift-monitoring", "blocking", 2, 0),
+				},
+			}
+		})
+		It("returns the namespaced name of each blocking PodDisruptionBudget", func() {
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *pdbList).Return(nil)
+			blocking, err := GetBlockingPdbs(mockKubeClient, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(blocking).To(ConsistOf("app/blocking", "openshift-monitoring/blocking"))
+		})
+		It("does not return PodDisruptionBudgets in excluded namespaces", func() {
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *pdbList).Return(nil)
+			blocking, err := GetBlockingPdbs(mockKubeClient, []string{"^openshift-.*"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(blocking).To(ConsistOf("app/blocking"))
+		})
+		It("returns an error if an excluded namespace is invalid", func() {
+			_, err := GetBlockingPdbs(mockKubeClient, []string{"("})
+			Expect(err).To(HaveOccurred())
+		})
+		It("returns an error if PodDisruptionBudgets can't be listed", func() {
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error"))
+			_, err := GetBlockingPdbs(mockKubeClient, nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,92 @@
+package drain
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
+)
+
+// NodeDrainPlan describes the pods each timed drain strategy would act on when draining a node
+type NodeDrainPlan struct {
+	NodeName string `json:"nodeName"`
+	// CordonedAt is when the node was cordoned, if it is
+	CordonedAt *metav1.Time     `json:"cordonedAt,omitempty"`
+	Steps      []*DrainPlanStep `json:"steps"`
+}
+
+// DrainPlanStep describes the pods a timed drain strategy would act on, and when
+type DrainPlanStep struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// WaitDuration is the time after the node is cordoned before the strategy acts
+	WaitDuration metav1.Duration `json:"waitDuration"`
+	// ExecuteAt is when the strategy acts, if the node is cordoned
+	ExecuteAt *metav1.Time `json:"executeAt,omitempty"`
+	// Pods are the namespaced names of the pods the strategy would currently act on
+	Pods []string `json:"pods"`
+}
+
+// GetPlan returns the drain plan for the node, listing the pods each timed strategy would act on if it were
+// executed now. Steps are ordered by their wait duration.
+func (ds *osdDrainStrategy) GetPlan(node *corev1.Node) (*NodeDrainPlan, error) {
+	plan := &NodeDrainPlan{NodeName: node.Name}
+	result := ds.machinery.IsNodeCordoned(node)
+	if result.IsCordoned {
+		plan.CordonedAt = result.AddedAt
+	}
+
+	for _, tds := range ds.timedDrainStrategies {
+		pods, err := tds.GetStrategy().GetPodList(node)
+		if err != nil {
+			return nil, err
+		}
+		step := &DrainPlanStep{
+			Name:         tds.GetName(),
+			Description:  tds.GetDescription(),
+			WaitDuration: metav1.Duration{Duration: tds.GetWaitDuration()},
+			Pods:         []string{},
+		}
+		if plan.CordonedAt != nil {
+			executeAt := metav1.NewTime(plan.CordonedAt.Add(tds.GetWaitDuration()))
+			step.ExecuteAt = &executeAt
+		}
+		for _, p := range pods.Items {
+			step.Pods = append(step.Pods, types.NamespacedName{Namespace: p.Namespace, Name: p.Name}.String())
+		}
+		plan.Steps = append(plan.Steps, step)
+	}
+
+	sort.SliceStable(plan.Steps, func(i, j int) bool {
+		return plan.Steps[i].WaitDuration.Duration < plan.Steps[j].WaitDuration.Duration
+	})
+	return plan, nil
+}
+
+// GetWorkerDrainPlans returns the drain plan of every worker node in the cluster
+func GetWorkerDrainPlans(c client.Client, nds NodeDrainStrategy) ([]*NodeDrainPlan, error) {
+	nodeList := &corev1.NodeList{}
+	err := c.List(context.TODO(), nodeList)
+	if err != nil {
+		return nil, err
+	}
+
+	plans := []*NodeDrainPlan{}
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		if _, isMaster := node.Labels[machinery.MasterLabel]; isMaster {
+			continue
+		}
+		plan, err := nds.GetPlan(node)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
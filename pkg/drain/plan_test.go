@@ -0,0 +1,111 @@
+package drain
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
+	mockMachinery "github.com/openshift/managed-upgrade-operator/pkg/machinery/mocks"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Drain Plan", func() {
+
+	var (
+		mockCtrl            *gomock.Controller
+		mockKubeClient      *mocks.MockClient
+		mockMachineryClient *mockMachinery.MockMachinery
+		mockStrategyOne     *MockDrainStrategy
+		mockStrategyTwo     *MockDrainStrategy
+		osdDrain            NodeDrainStrategy
+		node                *corev1.Node
+		podList             *corev1.PodList
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		mockMachineryClient = mockMachinery.NewMockMachinery(mockCtrl)
+		mockStrategyOne = NewMockDrainStrategy(mockCtrl)
+		mockStrategyTwo = NewMockDrainStrategy(mockCtrl)
+		osdDrain = &osdDrainStrategy{
+			mockKubeClient,
+			mockMachineryClient,
+			&NodeDrain{},
+			[]TimedDrainStrategy{
+				newTimedStrategy("PDB-DELETE", "PDB pod deletion", 60*time.Minute, mockStrategyOne),
+				newTimedStrategy("EVICT", "Pod eviction", 0, mockStrategyTwo),
+			},
+		}
+		node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n1"}}
+		podList = &corev1.PodList{
+			Items: []corev1.Pod{
+				{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "app"}},
+			},
+		}
+	})
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("Previewing the drain plan of a node", func() {
+		It("should list the pods each strategy would act on in order of their wait duration", func() {
+			gomock.InOrder(
+				mockMachineryClient.EXPECT().IsNodeCordoned(node).Return(&machinery.IsCordonedResult{IsCordoned: false}),
+				mockStrategyOne.EXPECT().GetPodList(node).Return(podList, nil),
+				mockStrategyTwo.EXPECT().GetPodList(node).Return(&corev1.PodList{}, nil),
+			)
+			plan, err := osdDrain.GetPlan(node)
+			Expect(err).To(BeNil())
+			Expect(plan.NodeName).To(Equal("n1"))
+			Expect(plan.CordonedAt).To(BeNil())
+			Expect(plan.Steps).To(HaveLen(2))
+			Expect(plan.Steps[0].Name).To(Equal("EVICT"))
+			Expect(plan.Steps[0].Pods).To(BeEmpty())
+			Expect(plan.Steps[0].ExecuteAt).To(BeNil())
+			Expect(plan.Steps[1].Name).To(Equal("PDB-DELETE"))
+			Expect(plan.Steps[1].WaitDuration.Duration).To(Equal(60 * time.Minute))
+			Expect(plan.Steps[1].Pods).To(Equal([]string{"app/pod1"}))
+		})
+		It("should say when each strategy acts on a cordoned node", func() {
+			cordonedAt := &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}
+			gomock.InOrder(
+				mockMachineryClient.EXPECT().IsNodeCordoned(node).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: cordonedAt}),
+				mockStrategyOne.EXPECT().GetPodList(node).Return(podList, nil),
+				mockStrategyTwo.EXPECT().GetPodList(node).Return(podList, nil),
+			)
+			plan, err := osdDrain.GetPlan(node)
+			Expect(err).To(BeNil())
+			Expect(plan.CordonedAt).To(Equal(cordonedAt))
+			Expect(plan.Steps[0].ExecuteAt.Time).To(Equal(cordonedAt.Time))
+			Expect(plan.Steps[1].ExecuteAt.Time).To(Equal(cordonedAt.Add(60 * time.Minute)))
+		})
+	})
+
+	Context("Previewing the drain plans of the worker pool", func() {
+		It("should only preview worker nodes", func() {
+			nodeList := corev1.NodeList{
+				Items: []corev1.Node{
+					{ObjectMeta: metav1.ObjectMeta{Name: "master", Labels: map[string]string{machinery.MasterLabel: ""}}},
+					*node,
+				},
+			}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, nodeList),
+				mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: false}),
+				mockStrategyOne.EXPECT().GetPodList(gomock.Any()).Return(podList, nil),
+				mockStrategyTwo.EXPECT().GetPodList(gomock.Any()).Return(podList, nil),
+			)
+			plans, err := GetWorkerDrainPlans(mockKubeClient, osdDrain)
+			Expect(err).To(BeNil())
+			Expect(plans).To(HaveLen(1))
+			Expect(plans[0].NodeName).To(Equal("n1"))
+		})
+	})
+})
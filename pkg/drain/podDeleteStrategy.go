@@ -1,7 +1,7 @@
 package drain
 
 import (
-	"context"
+	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -12,28 +12,41 @@ import (
 type podDeletionStrategy struct {
 	client  client.Client
 	filters []pod.PodPredicate
+	// pdbs records the PodDisruptionBudget matched by each pod, if the filters evaluate them
+	pdbs *pdbMatcher
+	// events records the pods which are force deleted
+	events *drainEventRecorder
 }
 
 func (pds *podDeletionStrategy) Execute(node *corev1.Node) (*DrainStrategyResult, error) {
-	podsToDelete, err := pds.getPodList(node)
+	podsToDelete, err := pds.GetPodList(node)
 	if err != nil {
 		return nil, err
 	}
 
 	gp := int64(0)
 	res, err := pod.DeletePods(pds.client, podsToDelete, true, &client.DeleteOptions{GracePeriodSeconds: &gp})
+	pds.events.recordForcedPods(node, res.PodsMarkedForDeletion, forceDeletedReason)
 	if err != nil {
 		return nil, err
 	}
 
+	message := res.Message
+	if pds.pdbs != nil {
+		if pdbDescription := pds.pdbs.describeRecordedPdbs(podsToDelete); pdbDescription != "" {
+			message = fmt.Sprintf("%s. %s", message, pdbDescription)
+		}
+	}
+
 	return &DrainStrategyResult{
-		Message:     res.Message,
-		HasExecuted: res.NumMarkedForDeletion > 0,
+		Message:       message,
+		HasExecuted:   res.NumMarkedForDeletion > 0,
+		ForcedActions: newForcedDrainActions(node, res.PodsMarkedForDeletion, forceDeletedReason, pds.pdbs),
 	}, nil
 }
 
 func (pds *podDeletionStrategy) IsValid(node *corev1.Node) (bool, error) {
-	targetPods, err := pds.getPodList(node)
+	targetPods, err := pds.GetPodList(node)
 	if err != nil {
 		return false, err
 	}
@@ -41,9 +54,8 @@ func (pds *podDeletionStrategy) IsValid(node *corev1.Node) (bool, error) {
 	return len(targetPods.Items) > 0, nil
 }
 
-func (pds *podDeletionStrategy) getPodList(node *corev1.Node) (*corev1.PodList, error) {
-	allPods := &corev1.PodList{}
-	err := pds.client.List(context.TODO(), allPods)
+func (pds *podDeletionStrategy) GetPodList(node *corev1.Node) (*corev1.PodList, error) {
+	allPods, err := listPodsOnNode(pds.client, node)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,49 @@
+package drain
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/pod"
+)
+
+type podEvictionStrategy struct {
+	client client.Client
+	// kubeClient reaches the pod Eviction subresource, which the controller-runtime client cannot
+	kubeClient kubernetes.Interface
+	filters    []pod.PodPredicate
+}
+
+func (pes *podEvictionStrategy) Execute(node *corev1.Node) (*DrainStrategyResult, error) {
+	podsToEvict, err := pes.GetPodList(node)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pods evicted before an eviction failed are still reported
+	res, err := pod.EvictPods(pes.kubeClient, podsToEvict)
+	return &DrainStrategyResult{
+		Message:     res.Message,
+		HasExecuted: res.NumEvicted > 0,
+	}, err
+}
+
+func (pes *podEvictionStrategy) IsValid(node *corev1.Node) (bool, error) {
+	targetPods, err := pes.GetPodList(node)
+	if err != nil {
+		return false, err
+	}
+
+	return len(targetPods.Items) > 0, nil
+}
+
+func (pes *podEvictionStrategy) GetPodList(node *corev1.Node) (*corev1.PodList, error) {
+	allPods, err := listPodsOnNode(pes.client, node)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := append([]pod.PodPredicate{isOnNode(node), isNotTerminating}, pes.filters...)
+	return pod.FilterPods(allPods, filters...), nil
+}
@@ -0,0 +1,147 @@
+package drain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pod Eviction Strategy", func() {
+
+	var (
+		mockCtrl       *gomock.Controller
+		mockKubeClient *mocks.MockClient
+		kubeClient     *fake.Clientset
+		pes            *podEvictionStrategy
+		node           *corev1.Node
+		podList        corev1.PodList
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		kubeClient = fake.NewSimpleClientset()
+		kubeClient.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, nil
+		})
+		node = &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "n1",
+			},
+		}
+		pes = &podEvictionStrategy{
+			client:     mockKubeClient,
+			kubeClient: kubeClient,
+		}
+
+		podList = corev1.PodList{
+			Items: []corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "pod1",
+					},
+					Spec: corev1.PodSpec{
+						NodeName: "n1",
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "pod2",
+						DeletionTimestamp: &metav1.Time{Time: time.Now()},
+					},
+					Spec: corev1.PodSpec{
+						NodeName: "n1",
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "pod3",
+					},
+					Spec: corev1.PodSpec{
+						NodeName: "n2",
+					},
+				},
+			},
+		}
+	})
+
+	Context("Execute pod eviction strategy on a node", func() {
+
+		It("Successfully evicts pods on a node", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, podList),
+			)
+			result, err := pes.Execute(node)
+			Expect(result.HasExecuted).To(BeTrue())
+			Expect(err).To(BeNil())
+			Expect(kubeClient.Actions()).To(HaveLen(1))
+		})
+
+		It("Does not execute if evictions are blocked by a PodDisruptionBudget", func() {
+			kubeClient.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+			})
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, podList),
+			)
+			result, err := pes.Execute(node)
+			Expect(result.HasExecuted).To(BeFalse())
+			Expect(err).To(BeNil())
+		})
+
+		It("Returns a result along with the error if an eviction fails", func() {
+			kubeClient.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, fmt.Errorf("fake error")
+			})
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, podList),
+			)
+			result, err := pes.Execute(node)
+			Expect(err).To(HaveOccurred())
+			Expect(result).NotTo(BeNil())
+			Expect(result.HasExecuted).To(BeFalse())
+		})
+
+		It("Returns error if fails to return a list of pods", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, podList).Return(fmt.Errorf("fake error")),
+			)
+			_, err := pes.Execute(node)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("Check if it's still valid to evict a pod", func() {
+		It("Returns true if there are target pods to be evicted", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, podList),
+			)
+			valid, err := pes.IsValid(node)
+			Expect(valid).To(BeTrue())
+			Expect(err).To(BeNil())
+		})
+
+		It("Returns false if the only pods on the node are terminating", func() {
+			podList.Items = podList.Items[1:]
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, podList),
+			)
+			valid, err := pes.IsValid(node)
+			Expect(valid).To(BeFalse())
+			Expect(err).To(BeNil())
+		})
+	})
+})
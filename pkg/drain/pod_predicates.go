@@ -1,21 +1,42 @@
 package drain
 
 import (
+	"regexp"
+
 	corev1 "k8s.io/api/core/v1"
-	policyv1beta1 "k8s.io/api/policy/v1beta1"
 
 	"github.com/openshift/managed-upgrade-operator/pkg/pod"
 )
 
-func isPdbPod(pdbList *policyv1beta1.PodDisruptionBudgetList) pod.PodPredicate {
+func isPdbPod(m *pdbMatcher) pod.PodPredicate {
+	return func(p corev1.Pod) bool {
+		_, ok := m.getMatchingPdb(p)
+		return ok
+	}
+}
+
+func isNotPdbPod(m *pdbMatcher) pod.PodPredicate {
 	return func(p corev1.Pod) bool {
-		return containsMatchLabel(p, pdbList)
+		_, ok := m.getMatchingPdb(p)
+		return !ok
 	}
 }
 
-func isNotPdbPod(pdbList *policyv1beta1.PodDisruptionBudgetList) pod.PodPredicate {
+func isInNamespaces(patterns []*regexp.Regexp) pod.PodPredicate {
 	return func(p corev1.Pod) bool {
-		return !containsMatchLabel(p, pdbList)
+		for _, r := range patterns {
+			if r.MatchString(p.Namespace) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func isNotInNamespaces(patterns []*regexp.Regexp) pod.PodPredicate {
+	inNamespaces := isInNamespaces(patterns)
+	return func(p corev1.Pod) bool {
+		return !inNamespaces(p)
 	}
 }
 
@@ -42,21 +63,6 @@ func isNotDaemonSet(pod corev1.Pod) bool {
 	return !isDaemonSet(pod)
 }
 
-func containsMatchLabel(p corev1.Pod, pdbList *policyv1beta1.PodDisruptionBudgetList) bool {
-	isPdbPod := false
-	for _, pdb := range pdbList.Items {
-		for mlKey, mlValue := range pdb.Spec.Selector.MatchLabels {
-			lValue, ok := p.Labels[mlKey]
-			if ok && lValue == mlValue {
-				isPdbPod = true
-				break
-			}
-		}
-	}
-
-	return isPdbPod
-}
-
 func hasFinalizers(p corev1.Pod) bool {
 	return len(p.ObjectMeta.GetFinalizers()) > 0
 }
@@ -68,3 +74,7 @@ func hasNoFinalizers(p corev1.Pod) bool {
 func isTerminating(p corev1.Pod) bool {
 	return p.DeletionTimestamp != nil
 }
+
+func isNotTerminating(p corev1.Pod) bool {
+	return !isTerminating(p)
+}
@@ -0,0 +1,47 @@
+package drain
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/pod"
+)
+
+const (
+	// drainProtectedAnnotation opts a pod, or every pod in a namespace, out of being forced from a node.
+	// Protected pods may still be evicted, but are never force deleted or have their finalizers removed.
+	drainProtectedAnnotation = "upgrade.managed.openshift.io/drain-protected"
+	// drainProtectedValue is the drainProtectedAnnotation value which protects pods
+	drainProtectedValue = "true"
+)
+
+// getDrainProtectedNamespaces returns the names of the namespaces whose pods are protected from forced drains
+func getDrainProtectedNamespaces(c client.Client) (map[string]bool, error) {
+	nsList := &corev1.NamespaceList{}
+	err := c.List(context.TODO(), nsList)
+	if err != nil {
+		return nil, err
+	}
+
+	protected := map[string]bool{}
+	for _, ns := range nsList.Items {
+		if ns.Annotations[drainProtectedAnnotation] == drainProtectedValue {
+			protected[ns.Name] = true
+		}
+	}
+	return protected, nil
+}
+
+func isDrainProtected(protectedNamespaces map[string]bool) pod.PodPredicate {
+	return func(p corev1.Pod) bool {
+		return p.Annotations[drainProtectedAnnotation] == drainProtectedValue || protectedNamespaces[p.Namespace]
+	}
+}
+
+func isNotDrainProtected(protectedNamespaces map[string]bool) pod.PodPredicate {
+	return func(p corev1.Pod) bool {
+		return !isDrainProtected(protectedNamespaces)(p)
+	}
+}
@@ -1,7 +1,7 @@
 package drain
 
 import (
-	"context"
+	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -12,27 +12,40 @@ import (
 type removeFinalizersStrategy struct {
 	client  client.Client
 	filters []pod.PodPredicate
+	// pdbs records the PodDisruptionBudget matched by each pod, if the filters evaluate them
+	pdbs *pdbMatcher
+	// events records the pods whose finalizers are removed
+	events *drainEventRecorder
 }
 
 func (rfs *removeFinalizersStrategy) Execute(node *corev1.Node) (*DrainStrategyResult, error) {
-	podsWithFinalizers, err := rfs.getPodList(node)
+	podsWithFinalizers, err := rfs.GetPodList(node)
 	if err != nil {
 		return nil, err
 	}
 
 	res, err := pod.RemoveFinalizersFromPod(rfs.client, podsWithFinalizers)
+	rfs.events.recordForcedPods(node, res.PodsWithFinalizersRemoved, finalizersRemovedReason)
 	if err != nil {
 		return nil, err
 	}
 
+	message := res.Message
+	if rfs.pdbs != nil {
+		if pdbDescription := rfs.pdbs.describeRecordedPdbs(podsWithFinalizers); pdbDescription != "" {
+			message = fmt.Sprintf("%s. %s", message, pdbDescription)
+		}
+	}
+
 	return &DrainStrategyResult{
-		Message:     res.Message,
-		HasExecuted: res.NumRemoved > 0,
+		Message:       message,
+		HasExecuted:   res.NumRemoved > 0,
+		ForcedActions: newForcedDrainActions(node, res.PodsWithFinalizersRemoved, finalizersRemovedReason, rfs.pdbs),
 	}, nil
 }
 
 func (rfs *removeFinalizersStrategy) IsValid(node *corev1.Node) (bool, error) {
-	targetPods, err := rfs.getPodList(node)
+	targetPods, err := rfs.GetPodList(node)
 	if err != nil {
 		return false, err
 	}
@@ -40,9 +53,8 @@ func (rfs *removeFinalizersStrategy) IsValid(node *corev1.Node) (bool, error) {
 	return len(targetPods.Items) > 0, nil
 }
 
-func (rfs *removeFinalizersStrategy) getPodList(node *corev1.Node) (*corev1.PodList, error) {
-	allPods := &corev1.PodList{}
-	err := rfs.client.List(context.TODO(), allPods)
+func (rfs *removeFinalizersStrategy) GetPodList(node *corev1.Node) (*corev1.PodList, error) {
+	allPods, err := listPodsOnNode(rfs.client, node)
 	if err != nil {
 		return nil, err
 	}
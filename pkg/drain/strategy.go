@@ -2,10 +2,13 @@ package drain
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
@@ -23,6 +26,9 @@ type NodeDrainStrategyBuilder interface {
 type NodeDrainStrategy interface {
 	Execute(*corev1.Node) ([]*DrainStrategyResult, error)
 	HasFailed(*corev1.Node) (bool, error)
+	GetProtectedPods(*corev1.Node) (*corev1.PodList, error)
+	GetStuckVolumeAttachments(*corev1.Node) ([]storagev1.VolumeAttachment, error)
+	GetPlan(*corev1.Node) (*NodeDrainPlan, error)
 }
 
 // DrainStrategy enables implementation for a DrainStrategy
@@ -30,6 +36,7 @@ type NodeDrainStrategy interface {
 type DrainStrategy interface {
 	Execute(*corev1.Node) (*DrainStrategyResult, error)
 	IsValid(*corev1.Node) (bool, error)
+	GetPodList(*corev1.Node) (*corev1.PodList, error)
 }
 
 // TimedDrainStrategy enables implementation for a TimedDrainStrategy
@@ -41,12 +48,17 @@ type TimedDrainStrategy interface {
 	GetStrategy() DrainStrategy
 }
 
-// NewBuilder returns a drainStrategyBuilder
-func NewBuilder() NodeDrainStrategyBuilder {
-	return &drainStrategyBuilder{}
+// NewBuilder returns a drainStrategyBuilder, whose strategies evict pods with the given clientset
+func NewBuilder(kubeClient kubernetes.Interface) NodeDrainStrategyBuilder {
+	return &drainStrategyBuilder{
+		kubeClient: kubeClient,
+	}
 }
 
-type drainStrategyBuilder struct{}
+type drainStrategyBuilder struct {
+	// kubeClient reaches the pod Eviction subresource, which the controller-runtime client cannot
+	kubeClient kubernetes.Interface
+}
 
 func newTimedStrategy(name string, description string, waitDuration time.Duration, strategy DrainStrategy) TimedDrainStrategy {
 	return &timedStrategy{
@@ -65,45 +77,102 @@ func (dsb *drainStrategyBuilder) NewNodeDrainStrategy(c client.Client, uc *upgra
 		return nil, err
 	}
 
-	allPods := &corev1.PodList{}
-	err = c.List(context.TODO(), allPods)
+	protectedNamespaces, err := getDrainProtectedNamespaces(c)
 	if err != nil {
 		return nil, err
 	}
 
-	defaultOsdPodPredicates := []pod.PodPredicate{isNotDaemonSet}
-	isNotPdbPod := isNotPdbPod(pdbList)
-	isPdbPod := isPdbPod(pdbList)
+	pdbs := newPdbMatcher(pdbList)
+	events := newDrainEventRecorder(c, uc)
 	defaultDuration := cfg.GetTimeOutDuration()
 	pdbDuration := uc.GetPDBDrainTimeoutDuration()
-	ts := []TimedDrainStrategy{
-		newTimedStrategy(defaultPodDeleteName, "Default pod deletion", defaultDuration, &podDeletionStrategy{
-			client:  c,
-			filters: append(defaultOsdPodPredicates, isNotPdbPod),
-		}),
-		newTimedStrategy(defaultPodFinalizerRemovalName, "Default pod finalizer removal", defaultDuration, &removeFinalizersStrategy{
-			client:  c,
-			filters: append(defaultOsdPodPredicates, isNotPdbPod),
-		}),
-		newTimedStrategy(stuckTerminatingPodName, "Pod stuck terminating removal", defaultDuration, &stuckTerminatingStrategy{
+	ts := []TimedDrainStrategy{}
+	for _, sc := range cfg.GetStrategies() {
+		strategy, err := newDrainStrategy(c, dsb.kubeClient, pdbs, protectedNamespaces, events, cfg.GetExpectedDrainDuration(), sc)
+		if err != nil {
+			return nil, err
+		}
+		ts = append(ts, newTimedStrategy(sc.Name, sc.GetDescription(), sc.GetWaitDuration(defaultDuration, pdbDuration), strategy))
+	}
+
+	return NewNodeDrainStrategy(c, cfg, ts)
+}
+
+// newDrainStrategy returns the DrainStrategy configured by the given DrainStrategyConfig. Strategies which
+// force pods from a node never select drain protected pods, regardless of their configured filters, and record
+// Events for the pods they force. VolumeAttachments are only considered stuck once unused for the expected drain
+// duration, giving their volumes time to detach.
+func newDrainStrategy(c client.Client, kubeClient kubernetes.Interface, pdbs *pdbMatcher, protectedNamespaces map[string]bool, events *drainEventRecorder, expectedDrainDuration time.Duration, sc DrainStrategyConfig) (DrainStrategy, error) {
+	filters := []pod.PodPredicate{isNotDaemonSet}
+	if sc.Type != evictStrategyType {
+		filters = append(filters, isNotDrainProtected(protectedNamespaces))
+	}
+	var recordedPdbs *pdbMatcher
+	switch sc.PodFilter.PDB {
+	case pdbFilterCovered:
+		filters = append(filters, isPdbPod(pdbs))
+		recordedPdbs = pdbs
+	case pdbFilterNotCovered:
+		filters = append(filters, isNotPdbPod(pdbs))
+	}
+	if len(sc.PodFilter.Namespaces) > 0 {
+		namespaces, err := compileNamespacePatterns(sc.PodFilter.Namespaces)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, isInNamespaces(namespaces))
+	}
+	if len(sc.PodFilter.ExcludedNamespaces) > 0 {
+		excludedNamespaces, err := compileNamespacePatterns(sc.PodFilter.ExcludedNamespaces)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, isNotInNamespaces(excludedNamespaces))
+	}
+
+	switch sc.Type {
+	case evictStrategyType:
+		return &podEvictionStrategy{
+			client:     c,
+			kubeClient: kubeClient,
+			filters:    filters,
+		}, nil
+	case deleteStrategyType:
+		return &podDeletionStrategy{
 			client:  c,
-			filters: append(defaultOsdPodPredicates, isNotPdbPod),
-		}),
-		newTimedStrategy(pdbPodDeleteName, "PDB pod deletion", pdbDuration, &podDeletionStrategy{
+			filters: filters,
+			pdbs:    recordedPdbs,
+			events:  events,
+		}, nil
+	case removeFinalizersStrategyType:
+		return &removeFinalizersStrategy{
 			client:  c,
-			filters: append(defaultOsdPodPredicates, isPdbPod),
-		}),
-		newTimedStrategy(pdbPodFinalizerRemovalName, "PDB Pod finalizer removal", pdbDuration, &removeFinalizersStrategy{
+			filters: filters,
+			pdbs:    recordedPdbs,
+			events:  events,
+		}, nil
+	case stuckTerminatingStrategyType:
+		return &stuckTerminatingStrategy{
 			client:  c,
-			filters: append(defaultOsdPodPredicates, isPdbPod),
-		}),
+			filters: filters,
+			events:  events,
+		}, nil
+	case stuckVolumeAttachmentsStrategyType:
+		return &stuckVolumeAttachmentsStrategy{
+			client:    c,
+			tracker:   unusedVolumeAttachments,
+			unusedFor: expectedDrainDuration,
+			cleanUp:   sc.CleanUp,
+			events:    events,
+		}, nil
 	}
-
-	return NewNodeDrainStrategy(c, cfg, ts)
+	return nil, fmt.Errorf("drain strategy %s type %s is invalid", sc.Name, sc.Type)
 }
 
 // DrainStrategyResult holds fields illustrating a drain strategies result
 type DrainStrategyResult struct {
 	Message     string
 	HasExecuted bool
+	// ForcedActions records the actions forcefully taken on pods, if any
+	ForcedActions []upgradev1alpha1.ForcedDrainAction
 }
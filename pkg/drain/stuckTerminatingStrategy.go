@@ -1,8 +1,6 @@
 package drain
 
 import (
-	"context"
-
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -12,28 +10,32 @@ import (
 type stuckTerminatingStrategy struct {
 	client  client.Client
 	filters []pod.PodPredicate
+	// events records the pods which are force deleted
+	events *drainEventRecorder
 }
 
 func (sts *stuckTerminatingStrategy) Execute(node *corev1.Node) (*DrainStrategyResult, error) {
-	podsStuckTerminating, err := sts.getPodList(node)
+	podsStuckTerminating, err := sts.GetPodList(node)
 	if err != nil {
 		return nil, err
 	}
 
 	gp := int64(0)
 	res, err := pod.DeletePods(sts.client, podsStuckTerminating, false, &client.DeleteOptions{GracePeriodSeconds: &gp})
+	sts.events.recordForcedPods(node, res.PodsMarkedForDeletion, forceDeletedReason)
 	if err != nil {
 		return nil, err
 	}
 
 	return &DrainStrategyResult{
-		Message:     res.Message,
-		HasExecuted: res.NumMarkedForDeletion > 0,
+		Message:       res.Message,
+		HasExecuted:   res.NumMarkedForDeletion > 0,
+		ForcedActions: newForcedDrainActions(node, res.PodsMarkedForDeletion, forceDeletedReason, nil),
 	}, nil
 }
 
 func (sts *stuckTerminatingStrategy) IsValid(node *corev1.Node) (bool, error) {
-	targetPods, err := sts.getPodList(node)
+	targetPods, err := sts.GetPodList(node)
 	if err != nil {
 		return false, err
 	}
@@ -41,9 +43,8 @@ func (sts *stuckTerminatingStrategy) IsValid(node *corev1.Node) (bool, error) {
 	return len(targetPods.Items) > 0, nil
 }
 
-func (sts *stuckTerminatingStrategy) getPodList(node *corev1.Node) (*corev1.PodList, error) {
-	allPods := &corev1.PodList{}
-	err := sts.client.List(context.TODO(), allPods)
+func (sts *stuckTerminatingStrategy) GetPodList(node *corev1.Node) (*corev1.PodList, error) {
+	allPods, err := listPodsOnNode(sts.client, node)
 	if err != nil {
 		return nil, err
 	}
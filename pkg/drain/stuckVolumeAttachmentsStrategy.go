@@ -0,0 +1,216 @@
+package drain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// volumeAttachmentStrategy is implemented by drain strategies which detect VolumeAttachments stuck on a node
+type volumeAttachmentStrategy interface {
+	// GetStuckVolumeAttachments returns the VolumeAttachments which have been stuck on the node for at least the
+	// given duration
+	GetStuckVolumeAttachments(node *corev1.Node, stuckFor time.Duration) ([]storagev1.VolumeAttachment, error)
+}
+
+// unusedVolumeAttachments is shared by the stuck VolumeAttachment strategies, which are rebuilt on every reconcile
+var unusedVolumeAttachments = newVolumeAttachmentTracker()
+
+type stuckVolumeAttachmentsStrategy struct {
+	client client.Client
+	// tracker records how long the VolumeAttachments on each node have been unused
+	tracker *volumeAttachmentTracker
+	// unusedFor is how long a VolumeAttachment must be unused before it is stuck, giving its volume time to detach
+	unusedFor time.Duration
+	// cleanUp removes the stuck VolumeAttachments, rather than only reporting them
+	cleanUp bool
+	// events records the VolumeAttachments which are removed
+	events *drainEventRecorder
+}
+
+func (svs *stuckVolumeAttachmentsStrategy) Execute(node *corev1.Node) (*DrainStrategyResult, error) {
+	stuck, err := svs.GetStuckVolumeAttachments(node, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(stuck) == 0 {
+		return &DrainStrategyResult{
+			Message:     fmt.Sprintf("No VolumeAttachments are stuck on node %s", node.Name),
+			HasExecuted: false,
+		}, nil
+	}
+	if !svs.cleanUp {
+		return &DrainStrategyResult{
+			Message:     fmt.Sprintf("VolumeAttachment(s) %s are stuck", strings.Join(volumeAttachmentNames(stuck), ",")),
+			HasExecuted: false,
+		}, nil
+	}
+
+	// Deleting a VolumeAttachment asks its attacher to detach the volume. If the attacher has already failed to,
+	// the VolumeAttachment's finalizers are removed instead.
+	me := &multierror.Error{}
+	var removed []storagev1.VolumeAttachment
+	for _, va := range stuck {
+		if va.DeletionTimestamp == nil {
+			err = svs.client.Delete(context.TODO(), &va)
+		} else if len(va.Finalizers) > 0 {
+			va.Finalizers = []string{}
+			err = svs.client.Update(context.TODO(), &va)
+		} else {
+			continue
+		}
+		if err != nil {
+			me = multierror.Append(me, err)
+			continue
+		}
+		removed = append(removed, va)
+	}
+	svs.events.recordRemovedVolumeAttachments(node, removed)
+
+	return &DrainStrategyResult{
+		Message:     fmt.Sprintf("VolumeAttachment(s) %s have been removed", strings.Join(volumeAttachmentNames(removed), ",")),
+		HasExecuted: len(removed) > 0,
+	}, me.ErrorOrNil()
+}
+
+func (svs *stuckVolumeAttachmentsStrategy) IsValid(node *corev1.Node) (bool, error) {
+	if !svs.cleanUp {
+		return false, nil
+	}
+	stuck, err := svs.GetStuckVolumeAttachments(node, 0)
+	if err != nil {
+		return false, err
+	}
+
+	return len(stuck) > 0, nil
+}
+
+// GetPodList returns no pods, as the strategy acts on the VolumeAttachments left behind by removed pods
+func (svs *stuckVolumeAttachmentsStrategy) GetPodList(node *corev1.Node) (*corev1.PodList, error) {
+	return &corev1.PodList{}, nil
+}
+
+// GetStuckVolumeAttachments returns the VolumeAttachments of persistent volumes to the node which no pod on the
+// node has used for the strategy's unusedFor duration, plus the given duration, and so are waiting to be detached
+func (svs *stuckVolumeAttachmentsStrategy) GetStuckVolumeAttachments(node *corev1.Node, stuckFor time.Duration) ([]storagev1.VolumeAttachment, error) {
+	vaList := &storagev1.VolumeAttachmentList{}
+	err := svs.client.List(context.TODO(), vaList)
+	if err != nil {
+		return nil, err
+	}
+
+	var attached []storagev1.VolumeAttachment
+	for _, va := range vaList.Items {
+		// Inline volumes are attached for as long as the pod using them exists, so only persistent volumes are considered
+		if va.Spec.NodeName == node.Name && va.Spec.Source.PersistentVolumeName != nil {
+			attached = append(attached, va)
+		}
+	}
+	if len(attached) == 0 {
+		svs.tracker.track(node.Name, nil, time.Now())
+		return nil, nil
+	}
+
+	inUse, err := svs.getVolumesInUse(node)
+	if err != nil {
+		return nil, err
+	}
+
+	var unused []storagev1.VolumeAttachment
+	for _, va := range attached {
+		if !inUse[*va.Spec.Source.PersistentVolumeName] {
+			unused = append(unused, va)
+		}
+	}
+
+	now := time.Now()
+	unusedSince := svs.tracker.track(node.Name, unused, now)
+	var stuck []storagev1.VolumeAttachment
+	for _, va := range unused {
+		if !unusedSince[va.UID].Add(svs.unusedFor + stuckFor).After(now) {
+			stuck = append(stuck, va)
+		}
+	}
+	return stuck, nil
+}
+
+// getVolumesInUse returns the names of the persistent volumes claimed by the pods on the node
+func (svs *stuckVolumeAttachmentsStrategy) getVolumesInUse(node *corev1.Node) (map[string]bool, error) {
+	pods, err := listPodsOnNode(svs.client, node)
+	if err != nil {
+		return nil, err
+	}
+
+	inUse := map[string]bool{}
+	for _, p := range pods.Items {
+		for _, v := range p.Spec.Volumes {
+			if v.PersistentVolumeClaim == nil {
+				continue
+			}
+			pvc := &corev1.PersistentVolumeClaim{}
+			err := svs.client.Get(context.TODO(), types.NamespacedName{Namespace: p.Namespace, Name: v.PersistentVolumeClaim.ClaimName}, pvc)
+			if err != nil {
+				if errors.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			if pvc.Spec.VolumeName != "" {
+				inUse[pvc.Spec.VolumeName] = true
+			}
+		}
+	}
+	return inUse, nil
+}
+
+func volumeAttachmentNames(vas []storagev1.VolumeAttachment) []string {
+	var names []string
+	for _, va := range vas {
+		names = append(names, va.Name)
+	}
+	return names
+}
+
+// volumeAttachmentTracker records when each VolumeAttachment on a node was first seen unused
+type volumeAttachmentTracker struct {
+	mutex       sync.Mutex
+	unusedSince map[string]map[types.UID]time.Time
+}
+
+func newVolumeAttachmentTracker() *volumeAttachmentTracker {
+	return &volumeAttachmentTracker{
+		unusedSince: map[string]map[types.UID]time.Time{},
+	}
+}
+
+// track records the VolumeAttachments currently unused on the node, forgetting those which are no longer, and
+// returns the time each was first seen unused. The returned map is never modified once returned.
+func (t *volumeAttachmentTracker) track(nodeName string, unused []storagev1.VolumeAttachment, now time.Time) map[types.UID]time.Time {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	previous := t.unusedSince[nodeName]
+	current := map[types.UID]time.Time{}
+	for _, va := range unused {
+		since, ok := previous[va.UID]
+		if !ok {
+			since = now
+		}
+		current[va.UID] = since
+	}
+	if len(current) == 0 {
+		delete(t.unusedSince, nodeName)
+	} else {
+		t.unusedSince[nodeName] = current
+	}
+	return current
+}
@@ -0,0 +1,275 @@
+package drain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stuck Volume Attachments Strategy", func() {
+
+	var (
+		mockCtrl       *gomock.Controller
+		mockKubeClient *mocks.MockClient
+		svs            *stuckVolumeAttachmentsStrategy
+		node           *corev1.Node
+		attachmentList storagev1.VolumeAttachmentList
+		podList        corev1.PodList
+		claim          corev1.PersistentVolumeClaim
+
+		NODENAME string
+	)
+
+	newAttachment := func(name string, nodeName string, pvName string) storagev1.VolumeAttachment {
+		return storagev1.VolumeAttachment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID(name + "-uid")},
+			Spec: storagev1.VolumeAttachmentSpec{
+				NodeName: nodeName,
+				Source:   storagev1.VolumeAttachmentSource{PersistentVolumeName: &pvName},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		NODENAME = "n1"
+
+		node = &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: NODENAME,
+			},
+		}
+		svs = &stuckVolumeAttachmentsStrategy{
+			client:  mockKubeClient,
+			tracker: newVolumeAttachmentTracker(),
+			cleanUp: true,
+		}
+
+		attachmentList = storagev1.VolumeAttachmentList{
+			Items: []storagev1.VolumeAttachment{
+				newAttachment("csi-in-use", NODENAME, "pv-in-use"),
+				newAttachment("csi-stuck", NODENAME, "pv-stuck"),
+				newAttachment("csi-other-node", "n2", "pv-other-node"),
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "csi-inline"},
+					Spec:       storagev1.VolumeAttachmentSpec{NodeName: NODENAME},
+				},
+			},
+		}
+		podList = corev1.PodList{
+			Items: []corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "db-0", Namespace: "app"},
+					Spec: corev1.PodSpec{
+						NodeName: NODENAME,
+						Volumes: []corev1.Volume{
+							{
+								Name: "data",
+								VolumeSource: corev1.VolumeSource{
+									PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data-db-0"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		claim = corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data-db-0", Namespace: "app"},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "pv-in-use"},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	expectStuckAttachmentLookup := func() *gomock.Call {
+		return mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Namespace: "app", Name: "data-db-0"}, gomock.Any()).SetArg(2, claim).After(
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, podList).After(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, attachmentList)))
+	}
+
+	Context("Get stuck volume attachments on a node", func() {
+		It("Returns the attachments of persistent volumes no pod on the node uses", func() {
+			expectStuckAttachmentLookup()
+			stuck, err := svs.GetStuckVolumeAttachments(node, 0)
+			Expect(err).To(BeNil())
+			Expect(stuck).To(HaveLen(1))
+			Expect(stuck[0].Name).To(Equal("csi-stuck"))
+		})
+
+		It("Only returns the attachments unused for at least the given duration", func() {
+			expectStuckAttachmentLookup()
+			stuck, err := svs.GetStuckVolumeAttachments(node, time.Minute)
+			Expect(err).To(BeNil())
+			Expect(stuck).To(BeEmpty())
+
+			svs.tracker = newVolumeAttachmentTracker()
+			svs.tracker.track(NODENAME, attachmentList.Items[1:2], time.Now().Add(-2*time.Minute))
+			expectStuckAttachmentLookup()
+			stuck, err = svs.GetStuckVolumeAttachments(node, time.Minute)
+			Expect(err).To(BeNil())
+			Expect(stuck).To(HaveLen(1))
+			Expect(stuck[0].Name).To(Equal("csi-stuck"))
+		})
+
+		It("Forgets attachments once they are used again", func() {
+			svs.tracker.track(NODENAME, attachmentList.Items[0:2], time.Now().Add(-2*time.Minute))
+			expectStuckAttachmentLookup()
+			_, err := svs.GetStuckVolumeAttachments(node, time.Minute)
+			Expect(err).To(BeNil())
+			Expect(svs.tracker.unusedSince[NODENAME]).To(HaveLen(1))
+			Expect(svs.tracker.unusedSince[NODENAME]).To(HaveKey(types.UID("csi-stuck-uid")))
+		})
+
+		It("Does not list pods if no persistent volume is attached to the node", func() {
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, storagev1.VolumeAttachmentList{
+				Items: []storagev1.VolumeAttachment{newAttachment("csi-other-node", "n2", "pv-other-node")},
+			})
+			stuck, err := svs.GetStuckVolumeAttachments(node, 0)
+			Expect(err).To(BeNil())
+			Expect(stuck).To(BeEmpty())
+		})
+
+		It("Returns error if fails to list volume attachments", func() {
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error"))
+			_, err := svs.GetStuckVolumeAttachments(node, 0)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("Execute stuck volume attachments strategy on a node", func() {
+		It("Successfully deletes stuck volume attachments", func() {
+			var deleted client.Object
+			gomock.InOrder(
+				expectStuckAttachmentLookup(),
+				mockKubeClient.EXPECT().Delete(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+						deleted = obj
+						return nil
+					}),
+			)
+			result, err := svs.Execute(node)
+			Expect(err).To(BeNil())
+			Expect(result.HasExecuted).To(BeTrue())
+			Expect(deleted.GetName()).To(Equal("csi-stuck"))
+		})
+
+		It("Removes the finalizers of stuck volume attachments which are already deleting", func() {
+			attachmentList.Items[1].DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			attachmentList.Items[1].Finalizers = []string{"external-attacher/ebs-csi-aws-com"}
+			var updated *storagev1.VolumeAttachment
+			gomock.InOrder(
+				expectStuckAttachmentLookup(),
+				mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, obj *storagev1.VolumeAttachment, opts ...client.UpdateOption) error {
+						updated = obj
+						return nil
+					}),
+			)
+			result, err := svs.Execute(node)
+			Expect(err).To(BeNil())
+			Expect(result.HasExecuted).To(BeTrue())
+			Expect(updated.Name).To(Equal("csi-stuck"))
+			Expect(updated.Finalizers).To(BeEmpty())
+		})
+
+		It("Records an Event against the node for each removed volume attachment", func() {
+			svs.events = newDrainEventRecorder(mockKubeClient, &upgradev1alpha1.UpgradeConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "managed-upgrade-config", Namespace: "test-namespace"},
+			})
+			var event *corev1.Event
+			gomock.InOrder(
+				expectStuckAttachmentLookup(),
+				mockKubeClient.EXPECT().Delete(gomock.Any(), gomock.Any()),
+				mockKubeClient.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+						event = obj.(*corev1.Event)
+						return nil
+					}),
+			)
+			_, err := svs.Execute(node)
+			Expect(err).To(BeNil())
+			Expect(event.InvolvedObject.Kind).To(Equal("Node"))
+			Expect(event.Reason).To(Equal(volumeAttachmentRemovedReason))
+			Expect(event.Message).To(Equal("VolumeAttachment csi-stuck of PersistentVolume pv-stuck was removed while draining node n1 for UpgradeConfig managed-upgrade-config"))
+		})
+
+		It("Does not remove volume attachments which have only just become unused", func() {
+			svs.unusedFor = time.Minute
+			expectStuckAttachmentLookup()
+			mockKubeClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(0)
+			result, err := svs.Execute(node)
+			Expect(err).To(BeNil())
+			Expect(result.HasExecuted).To(BeFalse())
+		})
+
+		It("Only reports stuck volume attachments if not cleaning them up", func() {
+			svs.cleanUp = false
+			expectStuckAttachmentLookup()
+			result, err := svs.Execute(node)
+			Expect(err).To(BeNil())
+			Expect(result.HasExecuted).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("csi-stuck"))
+		})
+
+		It("Reports that no volume attachments are stuck if there are none", func() {
+			attachmentList.Items = attachmentList.Items[0:1]
+			expectStuckAttachmentLookup()
+			result, err := svs.Execute(node)
+			Expect(err).To(BeNil())
+			Expect(result.HasExecuted).To(BeFalse())
+			Expect(result.Message).To(Equal("No VolumeAttachments are stuck on node n1"))
+		})
+
+		It("Returns error if failed to delete a stuck volume attachment", func() {
+			gomock.InOrder(
+				expectStuckAttachmentLookup(),
+				mockKubeClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error")),
+			)
+			result, err := svs.Execute(node)
+			Expect(err).To(HaveOccurred())
+			Expect(result.HasExecuted).To(BeFalse())
+		})
+	})
+
+	Context("Check if it's still valid to apply stuck volume attachments strategy on a node", func() {
+		It("Returns true if there are stuck volume attachments to clean up", func() {
+			expectStuckAttachmentLookup()
+			valid, err := svs.IsValid(node)
+			Expect(valid).To(BeTrue())
+			Expect(err).To(BeNil())
+		})
+
+		It("Returns false if volume attachments have only just become unused", func() {
+			svs.unusedFor = time.Minute
+			expectStuckAttachmentLookup()
+			valid, err := svs.IsValid(node)
+			Expect(valid).To(BeFalse())
+			Expect(err).To(BeNil())
+		})
+
+		It("Returns false if not cleaning up stuck volume attachments", func() {
+			svs.cleanUp = false
+			valid, err := svs.IsValid(node)
+			Expect(valid).To(BeFalse())
+			Expect(err).To(BeNil())
+		})
+	})
+})
@@ -2,6 +2,7 @@ package eventmanager
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
 	"github.com/openshift/managed-upgrade-operator/pkg/configmanager"
@@ -12,12 +13,32 @@ import (
 )
 
 const (
+	// UPGRADE_STARTED_DESC describes the upgrade commencing
+	UPGRADE_STARTED_DESC = "Cluster is currently being upgraded to version %s"
+	// UPGRADE_BLOCKING_PDBS_WARNING_DESC warns of PodDisruptionBudgets which will block node drains while upgrading
+	UPGRADE_BLOCKING_PDBS_WARNING_DESC = ". The following PodDisruptionBudgets allow no pods to be disrupted, so the pods they cover will be forcefully removed from worker nodes once their drain times out: %s"
+	// UPGRADE_COMPLETED_DESC describes the upgrade completing
+	UPGRADE_COMPLETED_DESC = "Cluster has been successfully upgraded to version %s"
+	// UPGRADE_DEGRADED_WORKLOADS_WARNING_DESC warns of customer workloads whose availability dropped over the course of the upgrade
+	UPGRADE_DEGRADED_WORKLOADS_WARNING_DESC = ". The following workloads have fewer ready replicas than before the upgrade, and may need attention: %s"
 	// UPGRADE_PRECHECK_FAILED_DESC describes the upgrade pre check failure
 	UPGRADE_PRECHECK_FAILED_DESC = "Cluster upgrade to version %s was cancelled as the cluster did not pass its pre-upgrade verification checks. Automated upgrades will be retried on their next scheduling cycle. If you have manually scheduled an upgrade instead, it must now be rescheduled."
 	// UPGRADE_PREHEALTHCHECK_FAILED_DESC describes the upgrade pre health check failure
 	UPGRADE_PREHEALTHCHECK_FAILED_DESC = "Cluster upgrade to version %s was cancelled during the Pre-Health Check step. Health alerts are firing in the cluster which could impact the upgrade's operation, so the upgrade did not proceed. Automated upgrades will be retried on their next scheduling cycle. If you have manually scheduled an upgrade instead, it must now be rescheduled."
+	// UPGRADE_BLOCKING_PDBS_FAILED_DESC describes the upgrade pre health check failure due to PodDisruptionBudgets blocking node drains
+	UPGRADE_BLOCKING_PDBS_FAILED_DESC = "Cluster upgrade to version %s was cancelled during the Pre-Health Check step as the following PodDisruptionBudgets allow no pods to be disrupted, and so would block worker nodes from draining: %s. These PodDisruptionBudgets must allow disruptions before the cluster can be upgraded. Automated upgrades will be retried on their next scheduling cycle. If you have manually scheduled an upgrade instead, it must now be rescheduled."
 	// UPGRADE_EXTDEPCHECK_FAILED_DESC describes the upgrade external dependency check failure
 	UPGRADE_EXTDEPCHECK_FAILED_DESC = "Cluster upgrade to version %s was cancelled during the External Dependency Availability Check step. A required external dependency of the upgrade was unavailable, so the upgrade did not proceed. Automated upgrades will be retried on their next scheduling cycle. If you have manually scheduled an upgrade instead, it must now be rescheduled."
+	// UPGRADE_INCOMPATIBLE_OPERATORS_FAILED_DESC describes the upgrade failing validation due to incompatible operators
+	UPGRADE_INCOMPATIBLE_OPERATORS_FAILED_DESC = "Cluster upgrade to version %s was cancelled as the following installed operators do not support it: %s. These operators must be upgraded or removed before the cluster can be upgraded. Automated upgrades will be retried on their next scheduling cycle. If you have manually scheduled an upgrade instead, it must now be rescheduled."
+	// UPGRADE_REMOVED_APIS_FAILED_DESC describes the upgrade failing validation due to usage of removed APIs
+	UPGRADE_REMOVED_APIS_FAILED_DESC = "Cluster upgrade to version %s was cancelled as APIs which are removed in that version are still in use: %s. These clients must be updated to use supported APIs before the cluster can be upgraded. Automated upgrades will be retried on their next scheduling cycle. If you have manually scheduled an upgrade instead, it must now be rescheduled."
+	// UPGRADE_ADMIN_ACKS_FAILED_DESC describes the upgrade failing validation due to pending administrator acknowledgements
+	UPGRADE_ADMIN_ACKS_FAILED_DESC = "Cluster upgrade to version %s was cancelled as it requires the following administrator acknowledgements which have not been given: %s. These must be acknowledged in the admin-acks ConfigMap in the openshift-config namespace before the cluster can be upgraded. Automated upgrades will be retried on their next scheduling cycle. If you have manually scheduled an upgrade instead, it must now be rescheduled."
+	// UPGRADE_CONDITIONAL_RISKS_FAILED_DESC describes the upgrade failing validation due to unaccepted conditional update risks
+	UPGRADE_CONDITIONAL_RISKS_FAILED_DESC = "Cluster upgrade to version %s was cancelled as the following known risks of the update apply to the cluster: %s. The upgrade may proceed once these risks no longer apply or have been accepted. Automated upgrades will be retried on their next scheduling cycle. If you have manually scheduled an upgrade instead, it must now be rescheduled."
+	// UPGRADE_WINDOW_BREACHED_FAILED_DESC describes the upgrade window closing before the upgrade commenced
+	UPGRADE_WINDOW_BREACHED_FAILED_DESC = "Cluster upgrade to version %s was cancelled as its upgrade window closed before the upgrade could commence, so no upgrade steps were performed. Automated upgrades will be retried on their next scheduling cycle. If you have manually scheduled an upgrade instead, it must now be rescheduled."
 	// UPGRADE_SCALE_FAILED_DESC describes the upgrade scaling failed
 	UPGRADE_SCALE_FAILED_DESC = "Cluster upgrade to version %s was cancelled during the Scale-Up Worker Node step. A temporary additional worker node was unable to be created to temporarily house workloads, so the upgrade did not proceed. Automated upgrades will be retried on their next scheduling cycle. If you have manually scheduled an upgrade instead, it must now be rescheduled."
 
@@ -25,6 +46,8 @@ const (
 	UPGRADE_DEFAULT_DELAY_DESC = "Cluster upgrade to version %s is experiencing a delay whilst it performs necessary pre-upgrade procedures. The upgrade will continue to retry. This is an informational notification and no action is required."
 	// UPGRADE_PREHEALTHCHECK_DELAY_DESC describes the upgrade pre health check delay
 	UPGRADE_PREHEALTHCHECK_DELAY_DESC = "Cluster upgrade to version %s is experiencing a delay as health alerts are firing in the cluster which could impact the upgrade's operation. The upgrade will continue to retry. This is an informational notification and no action is required by you."
+	// UPGRADE_BLOCKING_PDBS_DELAY_DESC describes the upgrade pre health check delay due to PodDisruptionBudgets blocking node drains
+	UPGRADE_BLOCKING_PDBS_DELAY_DESC = "Cluster upgrade to version %s is experiencing a delay as the following PodDisruptionBudgets allow no pods to be disrupted, and so would block worker nodes from draining: %s. The upgrade will continue to retry once these PodDisruptionBudgets allow disruptions."
 	// UPGRADE_EXTDEPCHECK_DELAY_DESC describes the upgrade external dependency check delay
 	UPGRADE_EXTDEPCHECK_DELAY_DESC = "Cluster upgrade to version %s is experiencing a delay as an external dependency of the upgrade is currently unavailable. The upgrade will continue to retry. This is an informational notification and no action is required by you."
 	// UPGRADE_SCALE_DELAY_DESC describes the upgrade scaling delayed
@@ -106,11 +129,11 @@ func (s *eventManager) Notify(state notifier.NotifyState) error {
 	var description string
 	switch state {
 	case notifier.StateStarted:
-		description = fmt.Sprintf("Cluster is currently being upgraded to version %s", uc.Spec.Desired.Version)
+		description = createStartedDescription(uc)
 	case notifier.StateDelayed:
 		description = createDelayedDescription(uc)
 	case notifier.StateCompleted:
-		description = fmt.Sprintf("Cluster has been successfully upgraded to version %s", uc.Spec.Desired.Version)
+		description = createCompletedDescription(uc)
 	case notifier.StateFailed:
 		description = createFailureDescription(uc)
 	default:
@@ -127,6 +150,28 @@ func (s *eventManager) Notify(state notifier.NotifyState) error {
 	return nil
 }
 
+// Generates a Started notification description, warning of any PodDisruptionBudgets which will block node drains
+func createStartedDescription(uc *v1alpha1.UpgradeConfig) string {
+	description := fmt.Sprintf(UPGRADE_STARTED_DESC, uc.Spec.Desired.Version)
+
+	history := uc.Status.History.GetHistory(uc.Spec.Desired.Version)
+	if history != nil && len(history.BlockingPodDisruptionBudgets) > 0 {
+		description += fmt.Sprintf(UPGRADE_BLOCKING_PDBS_WARNING_DESC, strings.Join(history.BlockingPodDisruptionBudgets, ", "))
+	}
+	return description
+}
+
+// Generates a Completed notification description, warning of any customer workloads which were degraded by the upgrade
+func createCompletedDescription(uc *v1alpha1.UpgradeConfig) string {
+	description := fmt.Sprintf(UPGRADE_COMPLETED_DESC, uc.Spec.Desired.Version)
+
+	history := uc.Status.History.GetHistory(uc.Spec.Desired.Version)
+	if history != nil && len(history.DegradedWorkloads) > 0 {
+		description += fmt.Sprintf(UPGRADE_DEGRADED_WORKLOADS_WARNING_DESC, strings.Join(history.DegradedWorkloads, ", "))
+	}
+	return description
+}
+
 // Generates a Failure notification description based on the UpgradeConfig's last failed state
 func createFailureDescription(uc *v1alpha1.UpgradeConfig) string {
 	// Default failure message
@@ -137,6 +182,25 @@ func createFailureDescription(uc *v1alpha1.UpgradeConfig) string {
 	if history == nil {
 		return description
 	}
+	// Handle an upgrade that never commenced as its window was breached
+	if history.StartTime == nil && history.Conditions.IsFalseFor(v1alpha1.UpgradeWindowCheck) {
+		return fmt.Sprintf(UPGRADE_WINDOW_BREACHED_FAILED_DESC, uc.Spec.Desired.Version)
+	}
+	// Handle an upgrade that never commenced as it failed validation
+	if history.StartTime == nil {
+		if len(history.IncompatibleOperators) > 0 {
+			return fmt.Sprintf(UPGRADE_INCOMPATIBLE_OPERATORS_FAILED_DESC, uc.Spec.Desired.Version, strings.Join(history.IncompatibleOperators, ", "))
+		}
+		if len(history.PendingAdminAcks) > 0 {
+			return fmt.Sprintf(UPGRADE_ADMIN_ACKS_FAILED_DESC, uc.Spec.Desired.Version, strings.Join(history.PendingAdminAcks, "; "))
+		}
+		if len(history.ConditionalUpdateRisks) > 0 {
+			return fmt.Sprintf(UPGRADE_CONDITIONAL_RISKS_FAILED_DESC, uc.Spec.Desired.Version, strings.Join(history.ConditionalUpdateRisks, ", "))
+		}
+		if len(history.RemovedAPIUsage) > 0 {
+			return fmt.Sprintf(UPGRADE_REMOVED_APIS_FAILED_DESC, uc.Spec.Desired.Version, strings.Join(history.RemovedAPIUsage, "; "))
+		}
+	}
 	// Handle no conditions available
 	if len(history.Conditions) == 0 {
 		return description
@@ -161,6 +225,10 @@ func createFailureDescription(uc *v1alpha1.UpgradeConfig) string {
 
 	switch failedCondition.Type {
 	case v1alpha1.UpgradePreHealthCheck:
+		if len(history.BlockingPodDisruptionBudgets) > 0 {
+			description = fmt.Sprintf(UPGRADE_BLOCKING_PDBS_FAILED_DESC, uc.Spec.Desired.Version, strings.Join(history.BlockingPodDisruptionBudgets, ", "))
+			break
+		}
 		description = fmt.Sprintf(UPGRADE_PREHEALTHCHECK_FAILED_DESC, uc.Spec.Desired.Version)
 	case v1alpha1.ExtDepAvailabilityCheck:
 		description = fmt.Sprintf(UPGRADE_EXTDEPCHECK_FAILED_DESC, uc.Spec.Desired.Version)
@@ -205,6 +273,10 @@ func createDelayedDescription(uc *v1alpha1.UpgradeConfig) string {
 
 	switch delayedCondition.Type {
 	case v1alpha1.UpgradePreHealthCheck:
+		if len(history.BlockingPodDisruptionBudgets) > 0 {
+			description = fmt.Sprintf(UPGRADE_BLOCKING_PDBS_DELAY_DESC, uc.Spec.Desired.Version, strings.Join(history.BlockingPodDisruptionBudgets, ", "))
+			break
+		}
 		description = fmt.Sprintf(UPGRADE_PREHEALTHCHECK_DELAY_DESC, uc.Spec.Desired.Version)
 	case v1alpha1.ExtDepAvailabilityCheck:
 		description = fmt.Sprintf(UPGRADE_EXTDEPCHECK_DELAY_DESC, uc.Spec.Desired.Version)
@@ -111,7 +111,65 @@ var _ = Describe("OCM Notifier", func() {
 				Expect(err).NotTo(BeNil())
 			})
 		})
+		Context("when workloads were degraded by the upgrade", func() {
+			It("warns of the degraded workloads", func() {
+				uc.Status.History[0].DegradedWorkloads = []string{"Deployment customer/web (ready 1/3)"}
+				expectedDescription := fmt.Sprintf(UPGRADE_COMPLETED_DESC, uc.Spec.Desired.Version) + fmt.Sprintf(UPGRADE_DEGRADED_WORKLOADS_WARNING_DESC, "Deployment customer/web (ready 1/3)")
+				gomock.InOrder(
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+				)
+				err := manager.Notify(testState)
+				Expect(err).To(BeNil())
+			})
+		})
+
+	})
+
+	Context("When notifying a started state", func() {
+		var uc upgradev1alpha1.UpgradeConfig
+		var testState = notifier.StateStarted
+		BeforeEach(func() {
+			upgradeConfigName = types.NamespacedName{
+				Name:      TEST_UPGRADECONFIG_CR,
+				Namespace: TEST_OPERATOR_NAMESPACE,
+			}
+			uc = *testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhaseUpgrading).GetUpgradeConfig()
+			uc.Spec.Desired.Version = TEST_UPGRADE_VERSION
+			uc.Status.History[0].Version = TEST_UPGRADE_VERSION
+			uc.Spec.UpgradeAt = TEST_UPGRADE_TIME
+		})
+
+		Context("when no PodDisruptionBudgets block node drains", func() {
+			It("sends a correct notification and description", func() {
+				expectedDescription := fmt.Sprintf(UPGRADE_STARTED_DESC, uc.Spec.Desired.Version)
+				gomock.InOrder(
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+				)
+				err := manager.Notify(testState)
+				Expect(err).To(BeNil())
+			})
+		})
 
+		Context("when PodDisruptionBudgets block node drains", func() {
+			It("warns of the PodDisruptionBudgets", func() {
+				uc.Status.History[0].BlockingPodDisruptionBudgets = []string{"app/pdb-a"}
+				expectedDescription := fmt.Sprintf(UPGRADE_STARTED_DESC, uc.Spec.Desired.Version) + fmt.Sprintf(UPGRADE_BLOCKING_PDBS_WARNING_DESC, "app/pdb-a")
+				gomock.InOrder(
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+				)
+				err := manager.Notify(testState)
+				Expect(err).To(BeNil())
+			})
+		})
 	})
 
 	Context("When notifying a failed state", func() {
@@ -150,6 +208,29 @@ var _ = Describe("OCM Notifier", func() {
 			})
 		})
 
+		Context("when the pre-health-check failed on blocking PodDisruptionBudgets", func() {
+			It("sends a notification naming the PodDisruptionBudgets", func() {
+				uc.Status.History[0].Conditions = []upgradev1alpha1.UpgradeCondition{
+					{
+						Type:    upgradev1alpha1.UpgradePreHealthCheck,
+						Status:  "False",
+						Reason:  "PreHealthCheck not done",
+						Message: "PodDisruptionBudgets blocking node drains: app/pdb-a, db/pdb-b",
+					},
+				}
+				uc.Status.History[0].BlockingPodDisruptionBudgets = []string{"app/pdb-a", "db/pdb-b"}
+				expectedDescription := fmt.Sprintf(UPGRADE_BLOCKING_PDBS_FAILED_DESC, uc.Spec.Desired.Version, "app/pdb-a, db/pdb-b")
+				gomock.InOrder(
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+				)
+				err := manager.Notify(testState)
+				Expect(err).To(BeNil())
+			})
+		})
+
 		Context("when the external dependency check failed", func() {
 			It("sends a correct notification and description", func() {
 				uc.Status.History[0].Conditions = []upgradev1alpha1.UpgradeCondition{
@@ -194,6 +275,74 @@ var _ = Describe("OCM Notifier", func() {
 			})
 		})
 
+		Context("when the upgrade window was breached before the upgrade commenced", func() {
+			It("sends a notification describing the breached window", func() {
+				uc.Status.History[0].RemovedAPIUsage = []string{"ingresses.v1beta1.extensions (removed in 1.22) used by system:admin"}
+				uc.Status.History[0].Conditions = []upgradev1alpha1.UpgradeCondition{
+					{
+						Type:    upgradev1alpha1.UpgradeWindowCheck,
+						Status:  "False",
+						Reason:  "UpgradeWindowBreached",
+						Message: "The upgrade window closed before the upgrade commenced",
+					},
+				}
+				expectedDescription := fmt.Sprintf(UPGRADE_WINDOW_BREACHED_FAILED_DESC, uc.Spec.Desired.Version)
+				gomock.InOrder(
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+				)
+				err := manager.Notify(testState)
+				Expect(err).To(BeNil())
+			})
+		})
+
+		Context("when installed operators do not support the desired version", func() {
+			It("sends a notification naming the operators", func() {
+				uc.Status.History[0].IncompatibleOperators = []string{"openshift-operators/my-operator.v1.0.0 (max 4.8)"}
+				expectedDescription := fmt.Sprintf(UPGRADE_INCOMPATIBLE_OPERATORS_FAILED_DESC, uc.Spec.Desired.Version, "openshift-operators/my-operator.v1.0.0 (max 4.8)")
+				gomock.InOrder(
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+				)
+				err := manager.Notify(testState)
+				Expect(err).To(BeNil())
+			})
+		})
+
+		Context("when removed APIs are in use", func() {
+			It("sends a notification naming the APIs", func() {
+				uc.Status.History[0].RemovedAPIUsage = []string{"ingresses.v1beta1.extensions (removed in 1.22) used by system:admin"}
+				expectedDescription := fmt.Sprintf(UPGRADE_REMOVED_APIS_FAILED_DESC, uc.Spec.Desired.Version, "ingresses.v1beta1.extensions (removed in 1.22) used by system:admin")
+				gomock.InOrder(
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+				)
+				err := manager.Notify(testState)
+				Expect(err).To(BeNil())
+			})
+		})
+
+		Context("when administrator acknowledgements are pending", func() {
+			It("sends a notification naming the acknowledgements", func() {
+				uc.Status.History[0].PendingAdminAcks = []string{"ack-4.8-kube-1.22-api-removals-in-4.9: Kubernetes 1.22 removes several APIs"}
+				expectedDescription := fmt.Sprintf(UPGRADE_ADMIN_ACKS_FAILED_DESC, uc.Spec.Desired.Version, "ack-4.8-kube-1.22-api-removals-in-4.9: Kubernetes 1.22 removes several APIs")
+				gomock.InOrder(
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+				)
+				err := manager.Notify(testState)
+				Expect(err).To(BeNil())
+			})
+		})
+
 		Context("when an indeterminate failure occurs", func() {
 			It("sends a correct default notification and description", func() {
 				uc.Status.History[0].Conditions = []upgradev1alpha1.UpgradeCondition{
@@ -254,6 +403,29 @@ var _ = Describe("OCM Notifier", func() {
 			})
 		})
 
+		Context("when the pre-health-check failed on blocking PodDisruptionBudgets", func() {
+			It("sends a notification naming the PodDisruptionBudgets", func() {
+				uc.Status.History[0].Conditions = []upgradev1alpha1.UpgradeCondition{
+					{
+						Type:    upgradev1alpha1.UpgradePreHealthCheck,
+						Status:  "False",
+						Reason:  "PreHealthCheck not done",
+						Message: "PodDisruptionBudgets blocking node drains: app/pdb-a, db/pdb-b",
+					},
+				}
+				uc.Status.History[0].BlockingPodDisruptionBudgets = []string{"app/pdb-a", "db/pdb-b"}
+				expectedDescription := fmt.Sprintf(UPGRADE_BLOCKING_PDBS_DELAY_DESC, uc.Spec.Desired.Version, "app/pdb-a, db/pdb-b")
+				gomock.InOrder(
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+				)
+				err := manager.Notify(testState)
+				Expect(err).To(BeNil())
+			})
+		})
+
 		Context("when the external dependency check failed", func() {
 			It("sends a correct notification and description", func() {
 				uc.Status.History[0].Conditions = []upgradev1alpha1.UpgradeCondition{
@@ -1,10 +1,14 @@
 package localprovider
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // LocalProviderConfig provides a configmanager for local provider
 type LocalProviderConfig struct {
 	ConfigManager ConfigManager `yaml:"configManager"`
+	UpgradeWindow UpgradeWindow `yaml:"upgradeWindow"`
 }
 
 // ConfigManager provides a LocalConfigName
@@ -12,6 +16,11 @@ type ConfigManager struct {
 	LocalConfigName string `yaml:"localConfigName"`
 }
 
+// UpgradeWindow provides the length of recurring upgrade windows which don't specify their own
+type UpgradeWindow struct {
+	TimeOut int `yaml:"timeOut" default:"120"`
+}
+
 // IsValid returns no error when the local provider config is valid
 func (lp *LocalProviderConfig) IsValid() error {
 	cfg := lp.ConfigManager.LocalConfigName
@@ -21,3 +30,8 @@ func (lp *LocalProviderConfig) IsValid() error {
 
 	return nil
 }
+
+// GetUpgradeWindowTimeOutDuration returns the upgrade window timeout as a duration
+func (lp *LocalProviderConfig) GetUpgradeWindowTimeOutDuration() time.Duration {
+	return time.Duration(lp.UpgradeWindow.TimeOut) * time.Minute
+}
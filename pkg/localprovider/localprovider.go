@@ -3,11 +3,15 @@ package localprovider
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/blang/semver"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
+	"github.com/openshift/managed-upgrade-operator/pkg/scheduler"
 	"github.com/openshift/managed-upgrade-operator/util"
 )
 
@@ -17,16 +21,20 @@ const UPGRADECONFIG_CR_NAME = "managed-upgrade-config"
 var log = logf.Log.WithName("upgradeconfig-localprovider")
 
 // New returns a new localProvider
-func New(c client.Client, name string) (*localProvider, error) {
+func New(c client.Client, name string, windowTimeOut time.Duration) (*localProvider, error) {
 	return &localProvider{
-		client:  c,
-		cfgname: name,
+		client:        c,
+		cfgname:       name,
+		cvClient:      cv.NewCVClient(c),
+		windowTimeOut: windowTimeOut,
 	}, nil
 }
 
 type localProvider struct {
-	client  client.Client
-	cfgname string
+	client        client.Client
+	cfgname       string
+	cvClient      cv.ClusterVersion
+	windowTimeOut time.Duration
 }
 
 // Get checks the upgrade config on the cluster with matched name which is going to perform the upgrade
@@ -39,8 +47,14 @@ func (l *localProvider) Get() ([]upgradev1alpha1.UpgradeConfigSpec, error) {
 		return nil, err
 	}
 
+	// Retry failed recurring upgrades in the next window
+	err = retryFailedRecurringUpgrades(l.client, *instances, l.windowTimeOut, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
 	// Get Specs from the upgradeConfig
-	specs, err := readSpecFromConfig(*instances)
+	specs, err := readSpecFromConfig(*instances, l.cvClient)
 	if err != nil {
 		return nil, err
 	}
@@ -49,19 +63,101 @@ func (l *localProvider) Get() ([]upgradev1alpha1.UpgradeConfigSpec, error) {
 }
 
 // Helper function to extract the spec from the upgradeConfig CR
-func readSpecFromConfig(ucl upgradev1alpha1.UpgradeConfigList) ([]upgradev1alpha1.UpgradeConfigSpec, error) {
+func readSpecFromConfig(ucl upgradev1alpha1.UpgradeConfigList, cvClient cv.ClusterVersion) ([]upgradev1alpha1.UpgradeConfigSpec, error) {
 	upgradeConfigSpecs := make([]upgradev1alpha1.UpgradeConfigSpec, 0)
 
 	for _, u := range ucl.Items {
-		// Completed UpgradeConfigs can be ignored
 		history := u.Status.History.GetHistory(u.Spec.Desired.Version)
-		if history != nil && history.Phase != upgradev1alpha1.UpgradePhaseUpgraded {
+		if history == nil {
+			continue
+		}
+		recurring := u.Spec.Schedule != nil
+		if history.Phase != upgradev1alpha1.UpgradePhaseUpgraded && !(recurring && history.Phase == upgradev1alpha1.UpgradePhaseFailed) {
 			upgradeConfigSpecs = append(upgradeConfigSpecs, u.Spec)
+			continue
+		}
+		// Completed UpgradeConfigs can be ignored, unless they recur on a schedule, in which
+		// case both upgraded and failed UpgradeConfigs move on to the next window
+		if recurring {
+			spec, err := nextRecurringSpec(u.Spec, cvClient)
+			if err != nil {
+				return nil, err
+			}
+			upgradeConfigSpecs = append(upgradeConfigSpecs, spec)
 		}
 	}
 	return upgradeConfigSpecs, nil
 }
 
+// retryFailedRecurringUpgrades clears the history of recurring upgrades which failed once their window has
+// closed, so that they are validated and scheduled again for the next window
+func retryFailedRecurringUpgrades(c client.Client, ucl upgradev1alpha1.UpgradeConfigList, windowTimeOut time.Duration, now time.Time) error {
+	for _, u := range ucl.Items {
+		history := u.Status.History.GetHistory(u.Spec.Desired.Version)
+		if u.Spec.Schedule == nil || history == nil || history.Phase != upgradev1alpha1.UpgradePhaseFailed {
+			continue
+		}
+		start, _, err := scheduler.GetRecurringWindow(*u.Spec.Schedule, now, windowTimeOut)
+		if err != nil {
+			return err
+		}
+		// Wait for the window the upgrade failed in to close, so it isn't retried straight away
+		if !start.After(now) {
+			continue
+		}
+
+		retried := u.DeepCopy()
+		retried.Status.History = upgradev1alpha1.UpgradeHistories{}
+		for _, h := range u.Status.History {
+			if h.Version != u.Spec.Desired.Version {
+				retried.Status.History = append(retried.Status.History, h)
+			}
+		}
+		err = c.Status().Update(context.TODO(), retried)
+		if err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("Recurring upgrade to version %s failed, retrying in the window opening at %s", u.Spec.Desired.Version, start.Format(time.RFC3339)))
+	}
+	return nil
+}
+
+// nextRecurringSpec returns the spec of a recurring upgrade to the latest z-stream version available to
+// the cluster. The spec is unchanged if no later z-stream version is available.
+func nextRecurringSpec(spec upgradev1alpha1.UpgradeConfigSpec, cvClient cv.ClusterVersion) (upgradev1alpha1.UpgradeConfigSpec, error) {
+	clusterVersion, err := cvClient.GetClusterVersion()
+	if err != nil {
+		return spec, err
+	}
+	current, err := cv.GetCurrentVersion(clusterVersion)
+	if err != nil {
+		return spec, err
+	}
+	currentVersion, err := semver.Parse(current)
+	if err != nil {
+		return spec, fmt.Errorf("unable to parse current version %s: %v", current, err)
+	}
+
+	latestVersion := currentVersion
+	for _, update := range clusterVersion.Status.AvailableUpdates {
+		version, err := semver.Parse(update.Version)
+		if err != nil {
+			continue
+		}
+		if version.Major == currentVersion.Major && version.Minor == currentVersion.Minor && version.GT(latestVersion) {
+			latestVersion = version
+		}
+	}
+	if latestVersion.EQ(currentVersion) {
+		return spec, nil
+	}
+
+	next := *spec.DeepCopy()
+	next.Desired.Version = latestVersion.String()
+	log.Info(fmt.Sprintf("Recurring upgrade moving on to latest z-stream version %s", next.Desired.Version))
+	return next, nil
+}
+
 // Read the CR from cluster with matched name
 func fetchUpgradeConfigs(c client.Client) (*upgradev1alpha1.UpgradeConfigList, error) {
 	instances := &upgradev1alpha1.UpgradeConfigList{}
@@ -1,17 +1,21 @@
 package localprovider
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+	cvMocks "github.com/openshift/managed-upgrade-operator/pkg/clusterversion/mocks"
 	"github.com/openshift/managed-upgrade-operator/util/mocks"
 )
 
@@ -30,6 +34,7 @@ var _ = Describe("Local Provider", func() {
 	var (
 		mockCtrl          *gomock.Controller
 		mockKubeClient    *mocks.MockClient
+		mockCVClient      *cvMocks.MockClusterVersion
 		provider          *localProvider
 		upgradeConfigList v1alpha1.UpgradeConfigList
 		upgradeConfigSpec v1alpha1.UpgradeConfigSpec
@@ -38,9 +43,11 @@ var _ = Describe("Local Provider", func() {
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		mockCVClient = cvMocks.NewMockClusterVersion(mockCtrl)
 		provider = &localProvider{
-			client:  mockKubeClient,
-			cfgname: TEST_LOCAL_UPGRADECONFIG_NAME,
+			client:   mockKubeClient,
+			cfgname:  TEST_LOCAL_UPGRADECONFIG_NAME,
+			cvClient: mockCVClient,
 		}
 
 		upgradeConfigSpec = v1alpha1.UpgradeConfigSpec{
@@ -114,7 +121,7 @@ var _ = Describe("Local Provider", func() {
 
 	Context("Read UpgradeConfigSpec from UpgradeConfigList", func() {
 		It("Returns UpgradeConfigList if UpgradeHistory is present", func() {
-			specs, err := readSpecFromConfig(upgradeConfigList)
+			specs, err := readSpecFromConfig(upgradeConfigList, mockCVClient)
 			Expect(err).To(BeNil())
 			Expect(specs).To(ContainElement(upgradeConfigSpec))
 		})
@@ -131,7 +138,7 @@ var _ = Describe("Local Provider", func() {
 					},
 				},
 			}
-			specs, err := readSpecFromConfig(emptyHistoryUCL)
+			specs, err := readSpecFromConfig(emptyHistoryUCL, mockCVClient)
 			Expect(err).To(BeNil())
 			Expect(specs).To(BeEmpty())
 		})
@@ -152,10 +159,106 @@ var _ = Describe("Local Provider", func() {
 					},
 				},
 			}
-			specs, err := readSpecFromConfig(upgradedHistoryUCL)
+			specs, err := readSpecFromConfig(upgradedHistoryUCL, mockCVClient)
 			Expect(err).To(BeNil())
 			Expect(specs).To(BeEmpty())
 		})
 
+		Context("When the UpgradeConfig recurs on a schedule", func() {
+			var upgradedHistoryUCL v1alpha1.UpgradeConfigList
+			var clusterVersion *configv1.ClusterVersion
+			BeforeEach(func() {
+				upgradeConfigSpec.Schedule = &v1alpha1.RecurringSchedule{Cron: "0 2 * * 2", TimeZone: "Europe/Berlin"}
+				upgradedHistoryUCL = v1alpha1.UpgradeConfigList{
+					Items: []v1alpha1.UpgradeConfig{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "uc1",
+							},
+							Spec: upgradeConfigSpec,
+							Status: v1alpha1.UpgradeConfigStatus{
+								History: []v1alpha1.UpgradeHistory{
+									{Version: TEST_LOCAL_UPGRADECONFIG_VERSION, Phase: v1alpha1.UpgradePhaseUpgraded},
+								},
+							},
+						},
+					},
+				}
+				clusterVersion = &configv1.ClusterVersion{
+					Status: configv1.ClusterVersionStatus{
+						History: []configv1.UpdateHistory{
+							{State: configv1.CompletedUpdate, Version: TEST_LOCAL_UPGRADECONFIG_VERSION},
+						},
+					},
+				}
+			})
+
+			It("Returns a spec for the latest z-stream version once upgraded", func() {
+				clusterVersion.Status.AvailableUpdates = []configv1.Release{
+					{Version: "4.7.13"},
+					{Version: "4.8.2"},
+					{Version: "4.7.12"},
+				}
+				mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil)
+				specs, err := readSpecFromConfig(upgradedHistoryUCL, mockCVClient)
+				Expect(err).To(BeNil())
+				Expect(specs).To(HaveLen(1))
+				Expect(specs[0].Desired.Version).To(Equal("4.7.13"))
+				Expect(specs[0].Schedule).To(Equal(upgradeConfigSpec.Schedule))
+			})
+
+			It("Returns the unchanged spec when no later z-stream version is available", func() {
+				mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil)
+				specs, err := readSpecFromConfig(upgradedHistoryUCL, mockCVClient)
+				Expect(err).To(BeNil())
+				Expect(specs).To(ContainElement(upgradeConfigSpec))
+			})
+
+			It("Returns a spec for the latest z-stream version once failed", func() {
+				upgradedHistoryUCL.Items[0].Status.History[0].Phase = v1alpha1.UpgradePhaseFailed
+				clusterVersion.Status.AvailableUpdates = []configv1.Release{{Version: "4.7.13"}}
+				mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil)
+				specs, err := readSpecFromConfig(upgradedHistoryUCL, mockCVClient)
+				Expect(err).To(BeNil())
+				Expect(specs).To(HaveLen(1))
+				Expect(specs[0].Desired.Version).To(Equal("4.7.13"))
+			})
+
+			Context("When the recurring upgrade has failed", func() {
+				var location *time.Location
+				BeforeEach(func() {
+					upgradedHistoryUCL.Items[0].Status.History = []v1alpha1.UpgradeHistory{
+						{Version: TEST_LOCAL_UPGRADECONFIG_VERSION, Phase: v1alpha1.UpgradePhaseFailed},
+						{Version: "4.7.10", Phase: v1alpha1.UpgradePhaseUpgraded},
+					}
+					location, _ = time.LoadLocation("Europe/Berlin")
+				})
+
+				It("Clears the failed history once its window has closed, so it is retried in the next window", func() {
+					mockUpdater := mocks.NewMockStatusWriter(mockCtrl)
+					gomock.InOrder(
+						mockKubeClient.EXPECT().Status().Return(mockUpdater),
+						mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+							func(ctx context.Context, uc *v1alpha1.UpgradeConfig, opts ...client.UpdateOption) error {
+								Expect(uc.Status.History).To(HaveLen(1))
+								Expect(uc.Status.History[0].Version).To(Equal("4.7.10"))
+								return nil
+							}),
+					)
+					// Tuesday, after the 02:00-04:00 window
+					now := time.Date(2021, 6, 1, 5, 0, 0, 0, location)
+					err := retryFailedRecurringUpgrades(mockKubeClient, upgradedHistoryUCL, 120*time.Minute, now)
+					Expect(err).To(BeNil())
+					Expect(upgradedHistoryUCL.Items[0].Status.History).To(HaveLen(2))
+				})
+
+				It("Does not retry the upgrade while its window is open", func() {
+					now := time.Date(2021, 6, 1, 3, 0, 0, 0, location)
+					err := retryFailedRecurringUpgrades(mockKubeClient, upgradedHistoryUCL, 120*time.Minute, now)
+					Expect(err).To(BeNil())
+				})
+			})
+		})
+
 	})
 })
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	machineconfigapi "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -30,3 +31,31 @@ func (m *machinery) IsUpgrading(c client.Client, nodeType string) (*UpgradingRes
 		MachineCount: configPool.Status.MachineCount,
 	}, nil
 }
+
+// HasDegradedPoolsResult holds the names of MachineConfigPools reporting a Degraded condition
+type HasDegradedPoolsResult struct {
+	Degraded []string
+}
+
+// HasDegradedPools returns the MachineConfigPools which report a Degraded condition
+func (m *machinery) HasDegradedPools(c client.Client) (*HasDegradedPoolsResult, error) {
+	poolList := &machineconfigapi.MachineConfigPoolList{}
+	err := c.List(context.TODO(), poolList)
+	if err != nil {
+		return nil, err
+	}
+
+	degradedPools := []string{}
+	for _, pool := range poolList.Items {
+		for _, condition := range pool.Status.Conditions {
+			if condition.Type == machineconfigapi.MachineConfigPoolDegraded && condition.Status == corev1.ConditionTrue {
+				degradedPools = append(degradedPools, pool.Name)
+				break
+			}
+		}
+	}
+
+	return &HasDegradedPoolsResult{
+		Degraded: degradedPools,
+	}, nil
+}
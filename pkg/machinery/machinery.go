@@ -16,6 +16,8 @@ const (
 type Machinery interface {
 	IsUpgrading(c client.Client, nodeType string) (*UpgradingResult, error)
 	IsNodeCordoned(node *corev1.Node) *IsCordonedResult
+	HasUnhealthyNodes(c client.Client) (*HasUnhealthyNodesResult, error)
+	HasDegradedPools(c client.Client) (*HasDegradedPoolsResult, error)
 }
 
 type machinery struct{}
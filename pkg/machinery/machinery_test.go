@@ -75,6 +75,112 @@ var _ = Describe("Machinery client and utils", func() {
 		})
 	})
 
+	Context("When assessing machine config pool health", func() {
+		It("reports the error", func() {
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(fmt.Errorf("Fake error"))
+			result, err := machineryClient.HasDegradedPools(mockKubeClient)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeNil())
+		})
+		It("reports degraded pools by name", func() {
+			poolList := &machineconfigapi.MachineConfigPoolList{
+				Items: []machineconfigapi.MachineConfigPool{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "master"},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+						Status: machineconfigapi.MachineConfigPoolStatus{
+							Conditions: []machineconfigapi.MachineConfigPoolCondition{
+								{Type: machineconfigapi.MachineConfigPoolDegraded, Status: corev1.ConditionTrue},
+							},
+						},
+					},
+				},
+			}
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *poolList).Return(nil)
+			result, err := machineryClient.HasDegradedPools(mockKubeClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Degraded).To(ConsistOf("worker"))
+		})
+	})
+
+	Context("When assessing node health", func() {
+		var readyCondition = corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionTrue}
+
+		It("reports the error", func() {
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(fmt.Errorf("Fake error"))
+			result, err := machineryClient.HasUnhealthyNodes(mockKubeClient)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeNil())
+		})
+		It("reports no unhealthy nodes when all nodes are ready and schedulable", func() {
+			nodeList := &corev1.NodeList{
+				Items: []corev1.Node{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "healthy"},
+						Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{readyCondition}},
+					},
+				},
+			}
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *nodeList).Return(nil)
+			result, err := machineryClient.HasUnhealthyNodes(mockKubeClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Unhealthy).To(BeEmpty())
+		})
+		It("reports unhealthy nodes by name and reason", func() {
+			nodeList := &corev1.NodeList{
+				Items: []corev1.Node{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "notready"},
+						Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+							{Type: corev1.NodeReady, Status: corev1.ConditionFalse},
+							{Type: corev1.NodeDiskPressure, Status: corev1.ConditionTrue},
+						}},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "pressure"},
+						Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+							readyCondition,
+							{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue},
+						}},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "cordoned"},
+						Spec:       corev1.NodeSpec{Unschedulable: true},
+						Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{readyCondition}},
+					},
+				},
+			}
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *nodeList).Return(nil)
+			result, err := machineryClient.HasUnhealthyNodes(mockKubeClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Unhealthy).To(ConsistOf(
+				"notready (NotReady, DiskPressure)",
+				"pressure (MemoryPressure)",
+				"cordoned (Unschedulable)",
+			))
+		})
+		It("does not report a node cordoned by the machine-config-daemon", func() {
+			nodeList := &corev1.NodeList{
+				Items: []corev1.Node{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        "updating",
+							Annotations: map[string]string{"machineconfiguration.openshift.io/state": "Working"},
+						},
+						Spec:   corev1.NodeSpec{Unschedulable: true},
+						Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{readyCondition}},
+					},
+				},
+			}
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *nodeList).Return(nil)
+			result, err := machineryClient.HasUnhealthyNodes(mockKubeClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Unhealthy).To(BeEmpty())
+		})
+	})
+
 	Context("When assessing if a node is cordoned", func() {
 		It("Reports if the node is draining", func() {
 			testNode := &corev1.Node{
@@ -35,6 +35,36 @@ func (m *MockMachinery) EXPECT() *MockMachineryMockRecorder {
 	return m.recorder
 }
 
+// HasDegradedPools mocks base method
+func (m *MockMachinery) HasDegradedPools(arg0 client.Client) (*machinery.HasDegradedPoolsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasDegradedPools", arg0)
+	ret0, _ := ret[0].(*machinery.HasDegradedPoolsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasDegradedPools indicates an expected call of HasDegradedPools
+func (mr *MockMachineryMockRecorder) HasDegradedPools(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasDegradedPools", reflect.TypeOf((*MockMachinery)(nil).HasDegradedPools), arg0)
+}
+
+// HasUnhealthyNodes mocks base method
+func (m *MockMachinery) HasUnhealthyNodes(arg0 client.Client) (*machinery.HasUnhealthyNodesResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasUnhealthyNodes", arg0)
+	ret0, _ := ret[0].(*machinery.HasUnhealthyNodesResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasUnhealthyNodes indicates an expected call of HasUnhealthyNodes
+func (mr *MockMachineryMockRecorder) HasUnhealthyNodes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasUnhealthyNodes", reflect.TypeOf((*MockMachinery)(nil).HasUnhealthyNodes), arg0)
+}
+
 // IsNodeCordoned mocks base method
 func (m *MockMachinery) IsNodeCordoned(arg0 *v1.Node) *machinery.IsCordonedResult {
 	m.ctrl.T.Helper()
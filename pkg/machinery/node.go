@@ -1,8 +1,14 @@
 package machinery
 
 import (
+	"context"
+	"fmt"
+	"strings"
+
+	mcoconst "github.com/openshift/machine-config-operator/pkg/daemon/constants"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // IsCordonedResult is a type that holds cordoned information
@@ -29,3 +35,56 @@ func (m *machinery) IsNodeCordoned(node *corev1.Node) *IsCordonedResult {
 		AddedAt:    cordonAddedTime,
 	}
 }
+
+// HasUnhealthyNodesResult holds the nodes found to be unhealthy, described as "name (reason)"
+type HasUnhealthyNodesResult struct {
+	Unhealthy []string
+}
+
+// HasUnhealthyNodes returns the nodes which are NotReady, under memory or disk
+// pressure, or unschedulable without the machine-config-daemon working on them
+func (m *machinery) HasUnhealthyNodes(c client.Client) (*HasUnhealthyNodesResult, error) {
+	nodeList := &corev1.NodeList{}
+	err := c.List(context.TODO(), nodeList)
+	if err != nil {
+		return nil, err
+	}
+
+	unhealthyNodes := []string{}
+	for _, node := range nodeList.Items {
+		problems := getNodeProblems(&node)
+		if len(problems) > 0 {
+			unhealthyNodes = append(unhealthyNodes, fmt.Sprintf("%s (%s)", node.Name, strings.Join(problems, ", ")))
+		}
+	}
+
+	return &HasUnhealthyNodesResult{
+		Unhealthy: unhealthyNodes,
+	}, nil
+}
+
+// getNodeProblems returns the reasons a node is considered unhealthy, if any
+func getNodeProblems(node *corev1.Node) []string {
+	problems := []string{}
+	ready := false
+	for _, condition := range node.Status.Conditions {
+		switch condition.Type {
+		case corev1.NodeReady:
+			ready = condition.Status == corev1.ConditionTrue
+		case corev1.NodeMemoryPressure, corev1.NodeDiskPressure:
+			if condition.Status == corev1.ConditionTrue {
+				problems = append(problems, string(condition.Type))
+			}
+		}
+	}
+	if !ready {
+		problems = append([]string{"NotReady"}, problems...)
+	}
+
+	// A node cordoned by the machine-config-daemon while it applies an update is expected
+	if node.Spec.Unschedulable && node.Annotations[mcoconst.MachineConfigDaemonStateAnnotationKey] != mcoconst.MachineConfigDaemonStateWorking {
+		problems = append(problems, "Unschedulable")
+	}
+
+	return problems
+}
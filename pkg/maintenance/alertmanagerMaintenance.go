@@ -13,6 +13,7 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/openshift/managed-upgrade-operator/config"
 	"github.com/openshift/managed-upgrade-operator/pkg/alertmanager"
+	"github.com/openshift/managed-upgrade-operator/pkg/alertscope"
 	amv2Models "github.com/prometheus/alertmanager/api/v2/models"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -30,7 +31,7 @@ var (
 
 type alertManagerMaintenanceBuilder struct{}
 
-func (ammb *alertManagerMaintenanceBuilder) NewClient(client client.Client) (Maintenance, error) {
+func (ammb *alertManagerMaintenanceBuilder) NewClient(client client.Client, scope alertscope.AlertScope) (Maintenance, error) {
 	transport, err := getTransport(client)
 	if err != nil {
 		return nil, err
@@ -45,12 +46,14 @@ func (ammb *alertManagerMaintenanceBuilder) NewClient(client client.Client) (Mai
 		client: &alertmanager.AlertManagerSilenceClient{
 			Transport: transport,
 		},
+		scope: scope,
 	}, nil
 }
 
 type alertManagerMaintenance struct {
 	//	client alertManagerSilenceClient
 	client alertmanager.AlertManagerSilencer
+	scope  alertscope.AlertScope
 }
 
 func getTransport(c client.Client) (*httptransport.Runtime, error) {
@@ -116,7 +119,7 @@ func (amm *alertManagerMaintenance) StartControlPlane(endsAt time.Time, version
 	now := strfmt.DateTime(time.Now().UTC())
 	end := strfmt.DateTime(endsAt.UTC())
 	if !defaultExists {
-		err = amm.client.Create(createDefaultMatchers(), now, end, config.OperatorName, defaultComment)
+		err = amm.client.Create(amm.getDefaultMatchers(), now, end, config.OperatorName, defaultComment)
 		if err != nil {
 			return err
 		}
@@ -163,7 +166,7 @@ func (amm *alertManagerMaintenance) SetWorker(endsAt time.Time, version string,
 			}
 		}
 		now := strfmt.DateTime(time.Now().UTC())
-		err = amm.client.Create(createDefaultMatchers(), now, end, config.OperatorName, fullComment)
+		err = amm.client.Create(amm.getDefaultMatchers(), now, end, config.OperatorName, fullComment)
 		if err != nil {
 			return err
 		}
@@ -207,12 +210,25 @@ func createMatcher(alertMatchKey string, alertValue string, isRegex bool) *amv2M
 	}
 }
 
-func createDefaultMatchers() []*amv2Models.Matcher {
+// getDefaultMatchers returns the matchers of the default silence
+func (amm *alertManagerMaintenance) getDefaultMatchers() []*amv2Models.Matcher {
+	return createDefaultMatchers(amm.scope, amm.scope.SilenceNamespaceRegex())
+}
+
+func createDefaultMatchers(scope alertscope.AlertScope, namespaceRegex string) []*amv2Models.Matcher {
 	// Upgrades can impact some availability which may trigger info/warning alerts. ignore those.
-	nonCriticalAlertMatcher := createMatcher("severity", "(warning|info)", true)
+	nonCriticalAlertMatcher := createMatcher("severity", "("+strings.Join(scope.GetSilencedSeverities(), "|")+")", true)
 
-	inNamespaceAlertMatcher := createMatcher("namespace", "(^openshift.*|^kube.*|^redhat.*|^default$)", true)
-	return amv2Models.Matchers{nonCriticalAlertMatcher, inNamespaceAlertMatcher}
+	inNamespaceAlertMatcher := createMatcher("namespace", namespaceRegex, true)
+	matchers := amv2Models.Matchers{nonCriticalAlertMatcher, inNamespaceAlertMatcher}
+	// Matchers cannot be negated, so excluded namespaces are left out by also matching every other namespace
+	if includedRegex, ok := scope.SilenceIncludedNamespaceRegex(); ok {
+		matchers = append(matchers, createMatcher("namespace", includedRegex, true))
+	}
+	for _, name := range scope.LabelNames() {
+		matchers = append(matchers, createMatcher(name, scope.LabelMatchers[name], true))
+	}
+	return matchers
 }
 
 func (amm *alertManagerMaintenance) IsActive() (bool, error) {
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/alertscope"
 )
 
 // Maintenance enables implementation of a maintenance interface type
@@ -20,7 +22,7 @@ type Maintenance interface {
 // MaintenanceBuilder enables an implementation of a maintenancebuilder interface type
 //go:generate mockgen -destination=mocks/maintenanceBuilder.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/maintenance MaintenanceBuilder
 type MaintenanceBuilder interface {
-	NewClient(client client.Client) (Maintenance, error)
+	NewClient(client client.Client, scope alertscope.AlertScope) (Maintenance, error)
 }
 
 // NewBuilder returns a MaintenanceBuilder
@@ -3,12 +3,14 @@ package maintenance
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/golang/mock/gomock"
 	routev1 "github.com/openshift/api/route/v1"
 	ammocks "github.com/openshift/managed-upgrade-operator/pkg/alertmanager/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/alertscope"
 	"github.com/openshift/managed-upgrade-operator/util/mocks"
 	amv2Models "github.com/prometheus/alertmanager/api/v2/models"
 	corev1 "k8s.io/api/core/v1"
@@ -40,7 +42,7 @@ var _ = Describe("Alert Manager Maintenance Client", func() {
 			Comment:   &testComment,
 			CreatedBy: &testCreatedByOperator,
 			EndsAt:    &testEnd,
-			Matchers:  createDefaultMatchers(),
+			Matchers:  createDefaultMatchers(alertscope.AlertScope{}, "(^openshift.*|^kube.*|^redhat.*|^default$)"),
 			StartsAt:  &testNow,
 		}
 
@@ -57,7 +59,7 @@ var _ = Describe("Alert Manager Maintenance Client", func() {
 					Comment:   &activeSilenceComment,
 					CreatedBy: &testCreatedByOperator,
 					EndsAt:    &testEnd,
-					Matchers:  createDefaultMatchers(),
+					Matchers:  createDefaultMatchers(alertscope.AlertScope{}, "(^openshift.*|^kube.*|^redhat.*|^default$)"),
 					StartsAt:  &testNow,
 				},
 			},
@@ -189,6 +191,44 @@ var _ = Describe("Alert Manager Maintenance Client", func() {
 			Expect(err).Should(Not(HaveOccurred()))
 		})
 	})
+	Context("Creating silence matchers", func() {
+		It("silences non-critical alerts in platform namespaces by default", func() {
+			matchers := maintenance.getDefaultMatchers()
+			Expect(matchers).To(HaveLen(2))
+			Expect(*matchers[0].Name).To(Equal("severity"))
+			Expect(*matchers[0].Value).To(Equal("(warning|info)"))
+			Expect(*matchers[1].Name).To(Equal("namespace"))
+			Expect(*matchers[1].Value).To(Equal("(^openshift.*|^kube.*|^redhat.*|^default$)"))
+		})
+		It("uses the configured alert scope", func() {
+			maintenance.scope = alertscope.AlertScope{
+				Namespaces:         []string{"^openshift-.*"},
+				SilencedSeverities: []string{"warning"},
+				LabelMatchers:      map[string]string{"prometheus": "openshift-monitoring/k8s"},
+			}
+			matchers := maintenance.getDefaultMatchers()
+			Expect(matchers).To(HaveLen(3))
+			Expect(*matchers[0].Value).To(Equal("(warning)"))
+			Expect(*matchers[1].Value).To(Equal("(^openshift-.*)"))
+			Expect(*matchers[2].Name).To(Equal("prometheus"))
+			Expect(*matchers[2].Value).To(Equal("openshift-monitoring/k8s"))
+		})
+		It("leaves the namespaces excluded from the alert scope out of the silence", func() {
+			maintenance.scope = alertscope.AlertScope{
+				ExcludedNamespaces: []string{"^openshift-logging$"},
+			}
+			matchers := maintenance.getDefaultMatchers()
+			Expect(matchers).To(HaveLen(3))
+			Expect(*matchers[1].Value).To(Equal("(^openshift.*|^kube.*|^redhat.*|^default$)"))
+			Expect(*matchers[2].Name).To(Equal("namespace"))
+			Expect(*matchers[2].IsRegex).To(BeTrue())
+			included := regexp.MustCompile("^(?:" + *matchers[2].Value + ")$")
+			Expect(included.MatchString("openshift-monitoring")).To(BeTrue())
+			Expect(included.MatchString("openshift-logging-new")).To(BeTrue())
+			Expect(included.MatchString("openshift-logging")).To(BeFalse())
+		})
+	})
+
 	// Finding and removing all active maintenances
 	Context("Build Alert Manager", func() {
 		It("Build an Alert Manager Client and not return an error", func() {
@@ -199,7 +239,7 @@ var _ = Describe("Alert Manager Maintenance Client", func() {
 			mockKubeClient.EXPECT().Get(context.TODO(), types.NamespacedName{Namespace: alertManagerNamespace, Name: alertManagerRouteName}, mockAmRoute)
 			mockKubeClient.EXPECT().List(context.TODO(), mockSecretList, &client.ListOptions{Namespace: alertManagerNamespace})
 
-			_, err := ammb.NewClient(mockKubeClient)
+			_, err := ammb.NewClient(mockKubeClient, alertscope.AlertScope{})
 			Expect(err).ShouldNot(HaveOccurred())
 		})
 	})
@@ -8,6 +8,7 @@ import (
 
 	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
 	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	"github.com/openshift/managed-upgrade-operator/pkg/scheduler"
 	ucm "github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager"
 )
 
@@ -64,10 +65,15 @@ func (uc *UpgradeCollector) collectUpgradeMetrics(ch chan<- prometheus.Metric) {
 		return
 	}
 
-	upgradeTime, err := time.Parse(time.RFC3339, upgradeConfig.Spec.UpgradeAt)
+	upgradeTime, _, err := scheduler.GetUpgradeWindow(upgradeConfig, time.Now(), 0)
 	if err != nil {
 		return
 	}
+	// Include any offset applied to stagger the upgrade's start
+	scheduledHistory := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+	if scheduledHistory != nil && scheduledHistory.ScheduleOffset != nil {
+		upgradeTime = upgradeTime.Add(scheduledHistory.ScheduleOffset.Duration)
+	}
 
 	// Set scheduled state value
 	ch <- prometheus.MustNewConstMetric(
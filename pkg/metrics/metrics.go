@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -57,6 +58,10 @@ type Metrics interface {
 	UpdateMetricNodeDrainFailed(string)
 	ResetMetricNodeDrainFailed(string)
 	ResetAllMetricNodeDrainFailed()
+	UpdateMetricNodeDrainProtectedPods(string, int)
+	ResetMetricNodeDrainProtectedPods(string)
+	UpdateMetricNodeDrainStuckVolumeAttachments(string, int)
+	ResetMetricNodeDrainStuckVolumeAttachments(string)
 	ResetFailureMetrics()
 	ResetAllMetrics()
 	UpdateMetricNotificationEventSent(string, string, string)
@@ -64,6 +69,7 @@ type Metrics interface {
 	IsMetricNotificationEventSentSet(upgradeConfigName string, event string, version string) (bool, error)
 	IsClusterVersionAtVersion(version string) (bool, error)
 	Query(query string) (*AlertResponse, error)
+	QueryRange(query string, start, end time.Time, step time.Duration) (*AlertResponse, error)
 }
 
 //go:generate mockgen -destination=mocks/metrics_builder.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/metrics MetricsBuilder
@@ -156,6 +162,16 @@ var (
 		Name:      "node_drain_timeout",
 		Help:      "Node cannot be drained successfully in time.",
 	}, []string{nodeLabel})
+	metricNodeDrainProtectedPods = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricsTag,
+		Name:      "node_drain_protected_pods",
+		Help:      "Number of drain protected pods blocking a node drain which has timed out.",
+	}, []string{nodeLabel})
+	metricNodeDrainStuckVolumeAttachments = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricsTag,
+		Name:      "node_drain_stuck_volume_attachments",
+		Help:      "Number of VolumeAttachments stuck on a node whose drain has timed out.",
+	}, []string{nodeLabel})
 	metricUpgradeNotification = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Subsystem: metricsTag,
 		Name:      "upgrade_notification",
@@ -171,6 +187,8 @@ var (
 		metricUpgradeControlPlaneTimeout,
 		metricUpgradeWorkerTimeout,
 		metricNodeDrainFailed,
+		metricNodeDrainProtectedPods,
+		metricNodeDrainStuckVolumeAttachments,
 		metricUpgradeNotification,
 	}
 )
@@ -269,6 +287,30 @@ func (c *Counter) ResetAllMetricNodeDrainFailed() {
 	metricNodeDrainFailed.Reset()
 }
 
+func (c *Counter) UpdateMetricNodeDrainProtectedPods(nodeName string, count int) {
+	metricNodeDrainProtectedPods.With(prometheus.Labels{
+		nodeLabel: nodeName}).Set(
+		float64(count))
+}
+
+func (c *Counter) ResetMetricNodeDrainProtectedPods(nodeName string) {
+	metricNodeDrainProtectedPods.With(prometheus.Labels{
+		nodeLabel: nodeName}).Set(
+		float64(0))
+}
+
+func (c *Counter) UpdateMetricNodeDrainStuckVolumeAttachments(nodeName string, count int) {
+	metricNodeDrainStuckVolumeAttachments.With(prometheus.Labels{
+		nodeLabel: nodeName}).Set(
+		float64(count))
+}
+
+func (c *Counter) ResetMetricNodeDrainStuckVolumeAttachments(nodeName string) {
+	metricNodeDrainStuckVolumeAttachments.With(prometheus.Labels{
+		nodeLabel: nodeName}).Set(
+		float64(0))
+}
+
 func (c *Counter) UpdateMetricUpgradeWindowNotBreached(upgradeConfigName string) {
 	metricUpgradeWindowBreached.With(prometheus.Labels{
 		nameLabel: upgradeConfigName}).Set(
@@ -305,6 +347,8 @@ func (c *Counter) ResetFailureMetrics() {
 		metricUpgradeControlPlaneTimeout,
 		metricUpgradeWorkerTimeout,
 		metricNodeDrainFailed,
+		metricNodeDrainProtectedPods,
+		metricNodeDrainStuckVolumeAttachments,
 	}
 	for _, m := range failureMetricsList {
 		m.Reset()
@@ -362,13 +406,31 @@ func getPromHost(c client.Client) (*string, error) {
 }
 
 func (c *Counter) Query(query string) (*AlertResponse, error) {
-	req, err := http.NewRequest("GET", "https://"+c.promHost+"/api/v1/query", nil)
+	return c.queryPrometheus("/api/v1/query", map[string]string{
+		"query": query,
+	})
+}
+
+// QueryRange evaluates a query over a range of time, returning the sampled values of each series
+func (c *Counter) QueryRange(query string, start, end time.Time, step time.Duration) (*AlertResponse, error) {
+	return c.queryPrometheus("/api/v1/query_range", map[string]string{
+		"query": query,
+		"start": strconv.FormatInt(start.Unix(), 10),
+		"end":   strconv.FormatInt(end.Unix(), 10),
+		"step":  strconv.FormatFloat(step.Seconds(), 'f', -1, 64),
+	})
+}
+
+func (c *Counter) queryPrometheus(path string, params map[string]string) (*AlertResponse, error) {
+	req, err := http.NewRequest("GET", "https://"+c.promHost+path, nil)
 	if err != nil {
 		return nil, fmt.Errorf("Could not query Prometheus: %s", err)
 	}
 
 	q := req.URL.Query()
-	q.Add("query", query)
+	for k, v := range params {
+		q.Add(k, v)
+	}
 	req.URL.RawQuery = q.Encode()
 	resp, err := c.promClient.Do(req)
 	if err != nil {
@@ -431,4 +493,21 @@ type AlertData struct {
 type AlertResult struct {
 	Metric map[string]string `json:"metric"`
 	Value  []interface{}     `json:"value"`
+	Values [][]interface{}   `json:"values"`
+}
+
+// GetSampleTimes returns the timestamps of the samples of a range query result
+func (r *AlertResult) GetSampleTimes() []time.Time {
+	times := []time.Time{}
+	for _, v := range r.Values {
+		if len(v) == 0 {
+			continue
+		}
+		ts, ok := v[0].(float64)
+		if !ok {
+			continue
+		}
+		times = append(times, time.Unix(0, int64(ts*float64(time.Second))))
+	}
+	return times
 }
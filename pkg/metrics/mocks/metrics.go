@@ -8,6 +8,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	metrics "github.com/openshift/managed-upgrade-operator/pkg/metrics"
 	reflect "reflect"
+	time "time"
 )
 
 // MockMetrics is a mock of Metrics interface
@@ -93,6 +94,21 @@ func (mr *MockMetricsMockRecorder) Query(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockMetrics)(nil).Query), arg0)
 }
 
+// QueryRange mocks base method
+func (m *MockMetrics) QueryRange(arg0 string, arg1, arg2 time.Time, arg3 time.Duration) (*metrics.AlertResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryRange", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*metrics.AlertResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryRange indicates an expected call of QueryRange
+func (mr *MockMetricsMockRecorder) QueryRange(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryRange", reflect.TypeOf((*MockMetrics)(nil).QueryRange), arg0, arg1, arg2, arg3)
+}
+
 // ResetAllMetricNodeDrainFailed mocks base method
 func (m *MockMetrics) ResetAllMetricNodeDrainFailed() {
 	m.ctrl.T.Helper()
@@ -141,6 +157,30 @@ func (mr *MockMetricsMockRecorder) ResetMetricNodeDrainFailed(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetMetricNodeDrainFailed", reflect.TypeOf((*MockMetrics)(nil).ResetMetricNodeDrainFailed), arg0)
 }
 
+// ResetMetricNodeDrainProtectedPods mocks base method
+func (m *MockMetrics) ResetMetricNodeDrainProtectedPods(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResetMetricNodeDrainProtectedPods", arg0)
+}
+
+// ResetMetricNodeDrainProtectedPods indicates an expected call of ResetMetricNodeDrainProtectedPods
+func (mr *MockMetricsMockRecorder) ResetMetricNodeDrainProtectedPods(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetMetricNodeDrainProtectedPods", reflect.TypeOf((*MockMetrics)(nil).ResetMetricNodeDrainProtectedPods), arg0)
+}
+
+// ResetMetricNodeDrainStuckVolumeAttachments mocks base method
+func (m *MockMetrics) ResetMetricNodeDrainStuckVolumeAttachments(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResetMetricNodeDrainStuckVolumeAttachments", arg0)
+}
+
+// ResetMetricNodeDrainStuckVolumeAttachments indicates an expected call of ResetMetricNodeDrainStuckVolumeAttachments
+func (mr *MockMetricsMockRecorder) ResetMetricNodeDrainStuckVolumeAttachments(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetMetricNodeDrainStuckVolumeAttachments", reflect.TypeOf((*MockMetrics)(nil).ResetMetricNodeDrainStuckVolumeAttachments), arg0)
+}
+
 // ResetMetricUpgradeConfigSynced mocks base method
 func (m *MockMetrics) ResetMetricUpgradeConfigSynced(arg0 string) {
 	m.ctrl.T.Helper()
@@ -213,6 +253,30 @@ func (mr *MockMetricsMockRecorder) UpdateMetricNodeDrainFailed(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetricNodeDrainFailed", reflect.TypeOf((*MockMetrics)(nil).UpdateMetricNodeDrainFailed), arg0)
 }
 
+// UpdateMetricNodeDrainProtectedPods mocks base method
+func (m *MockMetrics) UpdateMetricNodeDrainProtectedPods(arg0 string, arg1 int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateMetricNodeDrainProtectedPods", arg0, arg1)
+}
+
+// UpdateMetricNodeDrainProtectedPods indicates an expected call of UpdateMetricNodeDrainProtectedPods
+func (mr *MockMetricsMockRecorder) UpdateMetricNodeDrainProtectedPods(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetricNodeDrainProtectedPods", reflect.TypeOf((*MockMetrics)(nil).UpdateMetricNodeDrainProtectedPods), arg0, arg1)
+}
+
+// UpdateMetricNodeDrainStuckVolumeAttachments mocks base method
+func (m *MockMetrics) UpdateMetricNodeDrainStuckVolumeAttachments(arg0 string, arg1 int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateMetricNodeDrainStuckVolumeAttachments", arg0, arg1)
+}
+
+// UpdateMetricNodeDrainStuckVolumeAttachments indicates an expected call of UpdateMetricNodeDrainStuckVolumeAttachments
+func (mr *MockMetricsMockRecorder) UpdateMetricNodeDrainStuckVolumeAttachments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetricNodeDrainStuckVolumeAttachments", reflect.TypeOf((*MockMetrics)(nil).UpdateMetricNodeDrainStuckVolumeAttachments), arg0, arg1)
+}
+
 // UpdateMetricNotificationEventSent mocks base method
 func (m *MockMetrics) UpdateMetricNotificationEventSent(arg0, arg1, arg2 string) {
 	m.ctrl.T.Helper()
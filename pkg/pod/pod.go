@@ -7,6 +7,10 @@ import (
 
 	"github.com/hashicorp/go-multierror"
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -36,12 +40,15 @@ func FilterPods(podList *corev1.PodList, predicates ...PodPredicate) *corev1.Pod
 type DeleteResult struct {
 	Message              string
 	NumMarkedForDeletion int
+	// PodsMarkedForDeletion holds the pods which were successfully marked for deletion
+	PodsMarkedForDeletion []corev1.Pod
 }
 
 // DeletePods attempts to delete a given PodList and returns a DeleteResult and error
 func DeletePods(c client.Client, pl *corev1.PodList, ignoreAlreadyDeleting bool, options ...client.DeleteOption) (*DeleteResult, error) {
 	me := &multierror.Error{}
 	var podsMarkedForDeletion []string
+	var deleted []corev1.Pod
 	for _, p := range pl.Items {
 		if !ignoreAlreadyDeleting || p.DeletionTimestamp == nil {
 			err := c.Delete(context.TODO(), &p, options...)
@@ -49,13 +56,15 @@ func DeletePods(c client.Client, pl *corev1.PodList, ignoreAlreadyDeleting bool,
 				me = multierror.Append(err, me)
 			} else {
 				podsMarkedForDeletion = append(podsMarkedForDeletion, p.Name)
+				deleted = append(deleted, p)
 			}
 		}
 	}
 
 	return &DeleteResult{
-		Message:              fmt.Sprintf("Pod(s) %s have been marked for deletion", strings.Join(podsMarkedForDeletion, ",")),
-		NumMarkedForDeletion: len(podsMarkedForDeletion),
+		Message:               fmt.Sprintf("Pod(s) %s have been marked for deletion", strings.Join(podsMarkedForDeletion, ",")),
+		NumMarkedForDeletion:  len(podsMarkedForDeletion),
+		PodsMarkedForDeletion: deleted,
 	}, me.ErrorOrNil()
 }
 
@@ -63,11 +72,14 @@ func DeletePods(c client.Client, pl *corev1.PodList, ignoreAlreadyDeleting bool,
 type RemoveFinalizersResult struct {
 	Message    string
 	NumRemoved int
+	// PodsWithFinalizersRemoved holds the pods whose finalizers were successfully removed
+	PodsWithFinalizersRemoved []corev1.Pod
 }
 
 // RemoveFinalizersFromPod attempts to remove the finalizers from a given PodList and returns a RemoveFinalizersResult and error
 func RemoveFinalizersFromPod(c client.Client, pl *corev1.PodList) (*RemoveFinalizersResult, error) {
 	var podsWithFinalizersRemoved []string
+	var updated []corev1.Pod
 	me := &multierror.Error{}
 	for _, p := range pl.Items {
 		if len(p.ObjectMeta.GetFinalizers()) != 0 {
@@ -79,12 +91,63 @@ func RemoveFinalizersFromPod(c client.Client, pl *corev1.PodList) (*RemoveFinali
 				me = multierror.Append(err, me)
 			} else {
 				podsWithFinalizersRemoved = append(podsWithFinalizersRemoved, p.Name)
+				updated = append(updated, p)
 			}
 		}
 	}
 
 	return &RemoveFinalizersResult{
-		Message:    fmt.Sprintf("Finalizers removed for pods: %s", strings.Join(podsWithFinalizersRemoved, ",")),
-		NumRemoved: len(podsWithFinalizersRemoved),
+		Message:                   fmt.Sprintf("Finalizers removed for pods: %s", strings.Join(podsWithFinalizersRemoved, ",")),
+		NumRemoved:                len(podsWithFinalizersRemoved),
+		PodsWithFinalizersRemoved: updated,
+	}, me.ErrorOrNil()
+}
+
+// EvictResult holds fields describing the result of a pod eviction
+type EvictResult struct {
+	Message    string
+	NumEvicted int
+	NumBlocked int
+}
+
+// EvictPods attempts to evict a given PodList through the Eviction API, which honours PodDisruptionBudgets and
+// each pod's termination grace period, and returns an EvictResult and error. Evictions refused by a
+// PodDisruptionBudget are counted as blocked rather than returned as errors.
+func EvictPods(c kubernetes.Interface, pl *corev1.PodList) (*EvictResult, error) {
+	me := &multierror.Error{}
+	var podsEvicted []string
+	var podsBlocked []string
+	for _, p := range pl.Items {
+		if p.DeletionTimestamp != nil {
+			continue
+		}
+		eviction := &policyv1beta1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      p.Name,
+				Namespace: p.Namespace,
+			},
+		}
+		err := c.PolicyV1beta1().Evictions(p.Namespace).Evict(context.TODO(), eviction)
+		switch {
+		case err == nil:
+			podsEvicted = append(podsEvicted, p.Name)
+		case errors.IsNotFound(err):
+			// The pod has already gone
+		case errors.IsTooManyRequests(err):
+			podsBlocked = append(podsBlocked, p.Name)
+		default:
+			me = multierror.Append(err, me)
+		}
+	}
+
+	message := fmt.Sprintf("Pod(s) %s have been evicted", strings.Join(podsEvicted, ","))
+	if len(podsBlocked) > 0 {
+		message = fmt.Sprintf("%s. Eviction of pod(s) %s is blocked by a PodDisruptionBudget", message, strings.Join(podsBlocked, ","))
+	}
+
+	return &EvictResult{
+		Message:    message,
+		NumEvicted: len(podsEvicted),
+		NumBlocked: len(podsBlocked),
 	}, me.ErrorOrNil()
 }
@@ -1,13 +1,18 @@
 package pod
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/openshift/managed-upgrade-operator/util/mocks"
@@ -91,6 +96,7 @@ var _ = Describe("Pod Filter", func() {
 			result, err := RemoveFinalizersFromPod(mockKubeClient, podList)
 			Expect(err).To(BeNil())
 			Expect(result.NumRemoved).To(Equal(2))
+			Expect(result.PodsWithFinalizersRemoved).To(HaveLen(2))
 		})
 	})
 
@@ -130,6 +136,8 @@ var _ = Describe("Pod Filter", func() {
 				result, err := DeletePods(mockKubeClient, podList, true, &client.DeleteOptions{GracePeriodSeconds: &gp})
 				Expect(err).To(BeNil())
 				Expect(result.NumMarkedForDeletion).To(Equal(1))
+				Expect(result.PodsMarkedForDeletion).To(HaveLen(1))
+				Expect(result.PodsMarkedForDeletion[0].Name).To(Equal("testpod3"))
 			})
 			It("Should attempt to re-delete deleting pods if asked", func() {
 				gp := int64(0)
@@ -142,4 +150,69 @@ var _ = Describe("Pod Filter", func() {
 		})
 	})
 
+	Context("Evicting Pods", func() {
+		var (
+			podList    *corev1.PodList
+			kubeClient *fake.Clientset
+		)
+
+		BeforeEach(func() {
+			podList = &corev1.PodList{
+				Items: []corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:              "testpodBeingDeleted",
+							Namespace:         "testns",
+							DeletionTimestamp: &metav1.Time{Time: time.Now()},
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "testpod2",
+							Namespace: "testns",
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "testpod3",
+							Namespace: "testns",
+						},
+					},
+				},
+			}
+			kubeClient = fake.NewSimpleClientset()
+		})
+
+		It("Should evict pods that aren't already deleting", func() {
+			kubeClient.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, nil
+			})
+			result, err := EvictPods(kubeClient, podList)
+			Expect(err).To(BeNil())
+			Expect(result.NumEvicted).To(Equal(2))
+			Expect(result.NumBlocked).To(Equal(0))
+			Expect(kubeClient.Actions()).To(HaveLen(2))
+			Expect(kubeClient.Actions()[0].GetSubresource()).To(Equal("eviction"))
+		})
+
+		It("Should report evictions blocked by a PodDisruptionBudget without failing", func() {
+			kubeClient.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+			})
+			result, err := EvictPods(kubeClient, podList)
+			Expect(err).To(BeNil())
+			Expect(result.NumEvicted).To(Equal(0))
+			Expect(result.NumBlocked).To(Equal(2))
+			Expect(result.Message).To(ContainSubstring("blocked by a PodDisruptionBudget"))
+		})
+
+		It("Should return an error if an eviction fails", func() {
+			kubeClient.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.NewInternalError(fmt.Errorf("fake error"))
+			})
+			_, err := EvictPods(kubeClient, podList)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 })
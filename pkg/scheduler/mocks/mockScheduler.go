@@ -36,15 +36,15 @@ func (m *MockScheduler) EXPECT() *MockSchedulerMockRecorder {
 }
 
 // IsReadyToUpgrade mocks base method
-func (m *MockScheduler) IsReadyToUpgrade(arg0 *v1alpha1.UpgradeConfig, arg1 time.Duration) scheduler.SchedulerResult {
+func (m *MockScheduler) IsReadyToUpgrade(arg0 *v1alpha1.UpgradeConfig, arg1, arg2 time.Duration) scheduler.SchedulerResult {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsReadyToUpgrade", arg0, arg1)
+	ret := m.ctrl.Call(m, "IsReadyToUpgrade", arg0, arg1, arg2)
 	ret0, _ := ret[0].(scheduler.SchedulerResult)
 	return ret0
 }
 
 // IsReadyToUpgrade indicates an expected call of IsReadyToUpgrade
-func (mr *MockSchedulerMockRecorder) IsReadyToUpgrade(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockSchedulerMockRecorder) IsReadyToUpgrade(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsReadyToUpgrade", reflect.TypeOf((*MockScheduler)(nil).IsReadyToUpgrade), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsReadyToUpgrade", reflect.TypeOf((*MockScheduler)(nil).IsReadyToUpgrade), arg0, arg1, arg2)
 }
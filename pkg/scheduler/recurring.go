@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	// Embed the time zone database so recurring schedules can be evaluated in any time zone
+	_ "time/tzdata"
+
+	"github.com/robfig/cron/v3"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+)
+
+// defaultRecurringWindowLength is the length of a recurring upgrade window when neither the schedule
+// nor the upgrade window timeout specify one
+const defaultRecurringWindowLength = 120 * time.Minute
+
+// ValidateRecurringSchedule returns an error if the recurring schedule cannot be evaluated
+func ValidateRecurringSchedule(schedule upgradev1alpha1.RecurringSchedule) error {
+	_, _, err := parseRecurringSchedule(schedule)
+	if err != nil {
+		return err
+	}
+	if schedule.WindowLength < 0 {
+		return fmt.Errorf("schedule window length must not be negative")
+	}
+	return nil
+}
+
+// GetRecurringWindow returns the start and end of the recurring upgrade window which is open at the
+// given time, or otherwise of the next window to open. The window length defaults to the given timeout.
+func GetRecurringWindow(schedule upgradev1alpha1.RecurringSchedule, at time.Time, timeOut time.Duration) (time.Time, time.Time, error) {
+	cronSchedule, location, err := parseRecurringSchedule(schedule)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	length := time.Duration(schedule.WindowLength) * time.Minute
+	if length <= 0 {
+		length = timeOut
+	}
+	if length <= 0 {
+		length = defaultRecurringWindowLength
+	}
+
+	// The open window, if any, is the first to start after the window length before the given time
+	start := cronSchedule.Next(at.In(location).Add(-length))
+	if start.IsZero() {
+		return time.Time{}, time.Time{}, fmt.Errorf("schedule %s never occurs", schedule.Cron)
+	}
+	return start, start.Add(length), nil
+}
+
+func parseRecurringSchedule(schedule upgradev1alpha1.RecurringSchedule) (cron.Schedule, *time.Location, error) {
+	// The time zone is taken from the schedule's own field, so inline time zones would be ambiguous
+	if strings.HasPrefix(schedule.Cron, "TZ=") || strings.HasPrefix(schedule.Cron, "CRON_TZ=") {
+		return nil, nil, fmt.Errorf("schedule cron %s must not specify a time zone, use timeZone instead", schedule.Cron)
+	}
+	cronSchedule, err := cron.ParseStandard(schedule.Cron)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse schedule cron %s: %v", schedule.Cron, err)
+	}
+	location, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load schedule time zone %s: %v", schedule.TimeZone, err)
+	}
+	return cronSchedule, location, nil
+}
@@ -1,6 +1,8 @@
 package scheduler
 
 import (
+	"fmt"
+	"hash/fnv"
 	"time"
 
 	"github.com/prometheus/common/log"
@@ -11,7 +13,7 @@ import (
 // Scheduler is an interface that enables implementations of type Scheduler
 //go:generate mockgen -destination=mocks/mockScheduler.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/scheduler Scheduler
 type Scheduler interface {
-	IsReadyToUpgrade(*upgradev1alpha1.UpgradeConfig, time.Duration) SchedulerResult
+	IsReadyToUpgrade(*upgradev1alpha1.UpgradeConfig, time.Duration, time.Duration) SchedulerResult
 }
 
 type scheduler struct{}
@@ -28,16 +30,22 @@ type SchedulerResult struct {
 	TimeUntilUpgrade time.Duration
 }
 
-func (s *scheduler) IsReadyToUpgrade(upgradeConfig *upgradev1alpha1.UpgradeConfig, timeOut time.Duration) SchedulerResult {
-	upgradeTime, err := time.Parse(time.RFC3339, upgradeConfig.Spec.UpgradeAt)
+// IsReadyToUpgrade determines whether the upgrade window is open, where the window's start is delayed by the given offset
+func (s *scheduler) IsReadyToUpgrade(upgradeConfig *upgradev1alpha1.UpgradeConfig, timeOut time.Duration, offset time.Duration) SchedulerResult {
+	if upgradeConfig.Spec.Schedule != nil {
+		return isReadyToRecurringUpgrade(*upgradeConfig.Spec.Schedule, timeOut, offset)
+	}
+
+	upgradeTime, windowEnd, err := GetUpgradeWindow(upgradeConfig, time.Now(), timeOut)
 	if err != nil {
-		log.Error(err, "failed to parse spec.upgradeAt", upgradeConfig.Spec.UpgradeAt)
+		log.Error(err, "failed to determine the upgrade window")
 		return SchedulerResult{IsReady: false, IsBreached: false, TimeUntilUpgrade: 0}
 	}
+	upgradeTime = upgradeTime.Add(BoundOffset(offset, upgradeTime, windowEnd))
 	now := time.Now()
-	if now.After(upgradeTime) {
-		// Is the current time within the allowable upgrade window
-		if upgradeTime.Add(timeOut).After(now) {
+	if !now.Before(upgradeTime) {
+		// Is the current time within the allowable upgrade window, if one is enforced
+		if windowEnd.IsZero() || windowEnd.After(now) {
 			return SchedulerResult{IsReady: true, IsBreached: false, TimeUntilUpgrade: 0}
 		}
 
@@ -49,3 +57,109 @@ func (s *scheduler) IsReadyToUpgrade(upgradeConfig *upgradev1alpha1.UpgradeConfi
 	log.Infof("Upgrade is scheduled in %d hours %d mins", int(pendingTime.Hours()), int(pendingTime.Minutes())-(int(pendingTime.Hours())*60))
 	return SchedulerResult{IsReady: false, IsBreached: false, TimeUntilUpgrade: pendingTime}
 }
+
+// GetJitter returns an offset within the spread which is derived from the cluster ID, so that clusters
+// scheduled for the same time deterministically commence their upgrades at different times
+func GetJitter(clusterID string, spread time.Duration) time.Duration {
+	spreadSeconds := uint64(spread / time.Second)
+	if clusterID == "" || spreadSeconds == 0 {
+		return 0
+	}
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(clusterID))
+	return time.Duration(hash.Sum64()%spreadSeconds) * time.Second
+}
+
+// BoundOffset returns the offset into the upgrade window from start to end, wrapped to fall within the window
+// so that an upgrade whose start is offset still commences before the window closes. A zero end means the
+// window never closes, so any offset is within it.
+func BoundOffset(offset time.Duration, start time.Time, end time.Time) time.Duration {
+	length := end.Sub(start)
+	if end.IsZero() || length <= 0 || offset < length {
+		return offset
+	}
+	return offset % length
+}
+
+// GetUpgradeWindow returns the start and end of the UpgradeConfig's upgrade window. The window is taken from
+// spec.window, or from the recurring spec.schedule window open at the given time, or otherwise opens at
+// spec.upgradeAt for the given timeout. A zero end means the window never closes.
+func GetUpgradeWindow(upgradeConfig *upgradev1alpha1.UpgradeConfig, at time.Time, timeOut time.Duration) (time.Time, time.Time, error) {
+	if upgradeConfig.Spec.Schedule != nil {
+		return GetRecurringWindow(*upgradeConfig.Spec.Schedule, at, timeOut)
+	}
+
+	if upgradeConfig.Spec.Window != nil {
+		start, err := time.Parse(time.RFC3339, upgradeConfig.Spec.Window.Start)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("unable to parse spec.window.start %s: %v", upgradeConfig.Spec.Window.Start, err)
+		}
+		end, err := time.Parse(time.RFC3339, upgradeConfig.Spec.Window.End)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("unable to parse spec.window.end %s: %v", upgradeConfig.Spec.Window.End, err)
+		}
+		return start, end, nil
+	}
+
+	start, err := time.Parse(time.RFC3339, upgradeConfig.Spec.UpgradeAt)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("unable to parse spec.upgradeAt %s: %v", upgradeConfig.Spec.UpgradeAt, err)
+	}
+	if timeOut <= 0 {
+		return start, time.Time{}, nil
+	}
+	return start, start.Add(timeOut), nil
+}
+
+// GetWorkerWindowEnd returns the time by which worker nodes are expected to have upgraded, if the
+// UpgradeConfig's upgrade window specifies one
+func GetWorkerWindowEnd(upgradeConfig *upgradev1alpha1.UpgradeConfig) (time.Time, bool, error) {
+	if upgradeConfig.Spec.Window == nil || upgradeConfig.Spec.Window.WorkerEnd == "" {
+		return time.Time{}, false, nil
+	}
+	workerEnd, err := time.Parse(time.RFC3339, upgradeConfig.Spec.Window.WorkerEnd)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("unable to parse spec.window.workerEnd %s: %v", upgradeConfig.Spec.Window.WorkerEnd, err)
+	}
+	return workerEnd, true, nil
+}
+
+// ValidateUpgradeWindow returns an error if the upgrade window cannot be evaluated
+func ValidateUpgradeWindow(window upgradev1alpha1.UpgradeWindow) error {
+	uc := &upgradev1alpha1.UpgradeConfig{Spec: upgradev1alpha1.UpgradeConfigSpec{Window: &window}}
+	start, end, err := GetUpgradeWindow(uc, time.Time{}, 0)
+	if err != nil {
+		return err
+	}
+	if !end.After(start) {
+		return fmt.Errorf("spec.window.end %s must be after spec.window.start %s", window.End, window.Start)
+	}
+	workerEnd, ok, err := GetWorkerWindowEnd(uc)
+	if err != nil {
+		return err
+	}
+	// Workers only upgrade after the control plane, so a worker window ending before the upgrade window can never be met
+	if ok && workerEnd.Before(end) {
+		return fmt.Errorf("spec.window.workerEnd %s must not be before spec.window.end %s", window.WorkerEnd, window.End)
+	}
+	return nil
+}
+
+// isReadyToRecurringUpgrade is ready when a recurring upgrade window is open, once the given offset into
+// it has passed. A missed window is never breached, as the upgrade waits for the next window to open instead.
+func isReadyToRecurringUpgrade(schedule upgradev1alpha1.RecurringSchedule, timeOut time.Duration, offset time.Duration) SchedulerResult {
+	now := time.Now()
+	start, end, err := GetRecurringWindow(schedule, now, timeOut)
+	if err != nil {
+		log.Error(err, "failed to evaluate spec.schedule")
+		return SchedulerResult{IsReady: false, IsBreached: false, TimeUntilUpgrade: 0}
+	}
+	start = start.Add(BoundOffset(offset, start, end))
+	if !now.Before(start) {
+		return SchedulerResult{IsReady: true, IsBreached: false, TimeUntilUpgrade: 0}
+	}
+
+	pendingTime := start.Sub(now)
+	log.Infof("Upgrade window opens in %d hours %d mins", int(pendingTime.Hours()), int(pendingTime.Minutes())-(int(pendingTime.Hours())*60))
+	return SchedulerResult{IsReady: false, IsBreached: false, TimeUntilUpgrade: pendingTime}
+}
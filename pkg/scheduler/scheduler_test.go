@@ -1,6 +1,7 @@
 package scheduler
 
 import (
+	"fmt"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -18,22 +19,202 @@ var _ = Describe("Scheduler", func() {
 	It("should be ready to upgrade if upgradeAt is 10 mins before now", func() {
 		s := &scheduler{}
 		upgradeConfig = testUpgradeConfig(true, time.Now().Add(-10*time.Minute).Format(time.RFC3339))
-		result := s.IsReadyToUpgrade(upgradeConfig, 60*time.Minute)
+		result := s.IsReadyToUpgrade(upgradeConfig, 60*time.Minute, 0)
 		Expect(result.IsReady).To(BeTrue())
 	})
 	It("should be not ready to upgrade if upgradeAt is 80 mins before now", func() {
 		s := &scheduler{}
 		upgradeConfig = testUpgradeConfig(true, time.Now().Add(80*time.Minute).Format(time.RFC3339))
-		result := s.IsReadyToUpgrade(upgradeConfig, 60*time.Minute)
+		result := s.IsReadyToUpgrade(upgradeConfig, 60*time.Minute, 0)
 		Expect(result.IsReady).To(BeFalse())
 	})
 	It("it should not be ready to upgrade and indicate breach if upgradeAt is after timeout", func() {
 		s := &scheduler{}
 		upgradeConfig = testUpgradeConfig(true, time.Now().Add(-10*time.Minute).Format(time.RFC3339))
-		result := s.IsReadyToUpgrade(upgradeConfig, 5*time.Minute)
+		result := s.IsReadyToUpgrade(upgradeConfig, 5*time.Minute, 0)
 		Expect(result.IsReady).To(BeTrue())
 		Expect(result.IsBreached).To(BeTrue())
 	})
+	It("should not indicate breach if no upgrade window timeout is enforced", func() {
+		s := &scheduler{}
+		upgradeConfig = testUpgradeConfig(true, time.Now().Add(-10*time.Minute).Format(time.RFC3339))
+		result := s.IsReadyToUpgrade(upgradeConfig, 0, 0)
+		Expect(result.IsReady).To(BeTrue())
+		Expect(result.IsBreached).To(BeFalse())
+	})
+
+	Context("When the upgrade's start is offset", func() {
+		It("should not be ready to upgrade until the offset has passed", func() {
+			s := &scheduler{}
+			upgradeConfig = testUpgradeConfig(true, time.Now().Add(-10*time.Minute).Format(time.RFC3339))
+			result := s.IsReadyToUpgrade(upgradeConfig, 60*time.Minute, 20*time.Minute)
+			Expect(result.IsReady).To(BeFalse())
+			Expect(result.TimeUntilUpgrade).To(BeNumerically("~", 10*time.Minute, time.Minute))
+		})
+		It("should be ready to upgrade once the offset has passed", func() {
+			s := &scheduler{}
+			upgradeConfig = testUpgradeConfig(true, time.Now().Add(-10*time.Minute).Format(time.RFC3339))
+			result := s.IsReadyToUpgrade(upgradeConfig, 60*time.Minute, 5*time.Minute)
+			Expect(result.IsReady).To(BeTrue())
+			Expect(result.IsBreached).To(BeFalse())
+		})
+	})
+
+	Context("Jitter", func() {
+		It("should be deterministic for a cluster and within the spread", func() {
+			jitter := GetJitter("a6ba0ec0-4ef3-4bd0-bd82-5d8cf2b4b89a", 30*time.Minute)
+			Expect(jitter).To(Equal(GetJitter("a6ba0ec0-4ef3-4bd0-bd82-5d8cf2b4b89a", 30*time.Minute)))
+			Expect(jitter).To(BeNumerically(">=", 0))
+			Expect(jitter).To(BeNumerically("<", 30*time.Minute))
+		})
+		It("should differ between clusters", func() {
+			Expect(GetJitter("a6ba0ec0-4ef3-4bd0-bd82-5d8cf2b4b89a", 30*time.Minute)).NotTo(Equal(GetJitter("0b5c9b6e-2d4a-4f5e-9c1d-7e8f9a0b1c2d", 30*time.Minute)))
+		})
+		It("should be zero without a spread or cluster ID", func() {
+			Expect(GetJitter("a6ba0ec0-4ef3-4bd0-bd82-5d8cf2b4b89a", 0)).To(BeZero())
+			Expect(GetJitter("", 30*time.Minute)).To(BeZero())
+		})
+	})
+
+	Context("Bounding offsets", func() {
+		start := time.Date(2021, 6, 1, 2, 0, 0, 0, time.UTC)
+		It("should keep offsets within the window", func() {
+			Expect(BoundOffset(20*time.Minute, start, start.Add(time.Hour))).To(Equal(20 * time.Minute))
+		})
+		It("should wrap offsets beyond the window into it", func() {
+			Expect(BoundOffset(90*time.Minute, start, start.Add(time.Hour))).To(Equal(30 * time.Minute))
+		})
+		It("should keep offsets into windows which never close", func() {
+			Expect(BoundOffset(90*time.Minute, start, time.Time{})).To(Equal(90 * time.Minute))
+		})
+	})
+
+	Context("When the upgrade has an explicit window", func() {
+		It("should be ready to upgrade while the window is open", func() {
+			s := &scheduler{}
+			upgradeConfig = testUpgradeConfig(true, "")
+			upgradeConfig.Spec.Window = &upgradev1alpha1.UpgradeWindow{
+				Start: time.Now().Add(-10 * time.Minute).Format(time.RFC3339),
+				End:   time.Now().Add(3 * time.Hour).Format(time.RFC3339),
+			}
+			result := s.IsReadyToUpgrade(upgradeConfig, 5*time.Minute, 0)
+			Expect(result.IsReady).To(BeTrue())
+			Expect(result.IsBreached).To(BeFalse())
+		})
+		It("should indicate breach once the window has closed", func() {
+			s := &scheduler{}
+			upgradeConfig = testUpgradeConfig(true, "")
+			upgradeConfig.Spec.Window = &upgradev1alpha1.UpgradeWindow{
+				Start: time.Now().Add(-3 * time.Hour).Format(time.RFC3339),
+				End:   time.Now().Add(-10 * time.Minute).Format(time.RFC3339),
+			}
+			result := s.IsReadyToUpgrade(upgradeConfig, 5*time.Hour, 0)
+			Expect(result.IsReady).To(BeTrue())
+			Expect(result.IsBreached).To(BeTrue())
+		})
+		It("should not be ready to upgrade before the window opens", func() {
+			s := &scheduler{}
+			upgradeConfig = testUpgradeConfig(true, "")
+			upgradeConfig.Spec.Window = &upgradev1alpha1.UpgradeWindow{
+				Start: time.Now().Add(80 * time.Minute).Format(time.RFC3339),
+				End:   time.Now().Add(3 * time.Hour).Format(time.RFC3339),
+			}
+			result := s.IsReadyToUpgrade(upgradeConfig, 60*time.Minute, 0)
+			Expect(result.IsReady).To(BeFalse())
+			Expect(result.TimeUntilUpgrade).To(BeNumerically(">", 70*time.Minute))
+		})
+		It("should bound the offset so the upgrade commences before the window closes", func() {
+			s := &scheduler{}
+			upgradeConfig = testUpgradeConfig(true, "")
+			upgradeConfig.Spec.Window = &upgradev1alpha1.UpgradeWindow{
+				Start: time.Now().Add(-10 * time.Minute).Format(time.RFC3339),
+				End:   time.Now().Add(20 * time.Minute).Format(time.RFC3339),
+			}
+			result := s.IsReadyToUpgrade(upgradeConfig, 5*time.Hour, 45*time.Minute)
+			Expect(result.IsReady).To(BeFalse())
+			Expect(result.IsBreached).To(BeFalse())
+			Expect(result.TimeUntilUpgrade).To(BeNumerically("~", 5*time.Minute, time.Minute))
+		})
+		It("should reject windows which cannot be evaluated", func() {
+			Expect(ValidateUpgradeWindow(upgradev1alpha1.UpgradeWindow{Start: "tomorrow", End: "2021-06-01T02:00:00Z"})).To(HaveOccurred())
+			Expect(ValidateUpgradeWindow(upgradev1alpha1.UpgradeWindow{Start: "2021-06-01T02:00:00Z", End: "2021-06-01T00:00:00Z"})).To(HaveOccurred())
+			Expect(ValidateUpgradeWindow(upgradev1alpha1.UpgradeWindow{Start: "2021-06-01T00:00:00Z", End: "2021-06-01T02:00:00Z", WorkerEnd: "2021-05-31T00:00:00Z"})).To(HaveOccurred())
+			Expect(ValidateUpgradeWindow(upgradev1alpha1.UpgradeWindow{Start: "2021-06-01T00:00:00Z", End: "2021-06-01T02:00:00Z", WorkerEnd: "2021-06-01T01:00:00Z"})).To(MatchError(ContainSubstring("must not be before spec.window.end")))
+			Expect(ValidateUpgradeWindow(upgradev1alpha1.UpgradeWindow{Start: "2021-06-01T00:00:00Z", End: "2021-06-01T02:00:00Z", WorkerEnd: "2021-06-01T02:00:00Z"})).NotTo(HaveOccurred())
+			Expect(ValidateUpgradeWindow(upgradev1alpha1.UpgradeWindow{Start: "2021-06-01T00:00:00Z", End: "2021-06-01T02:00:00Z", WorkerEnd: "2021-06-01T06:00:00Z"})).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When the upgrade recurs on a schedule", func() {
+		It("should be ready to upgrade while a window is open", func() {
+			s := &scheduler{}
+			upgradeConfig = testUpgradeConfig(true, "")
+			upgradeConfig.Spec.Schedule = &upgradev1alpha1.RecurringSchedule{Cron: "* * * * *", WindowLength: 60}
+			result := s.IsReadyToUpgrade(upgradeConfig, 5*time.Minute, 0)
+			Expect(result.IsReady).To(BeTrue())
+			Expect(result.IsBreached).To(BeFalse())
+		})
+		It("should wait for the next window without indicating breach", func() {
+			s := &scheduler{}
+			upgradeConfig = testUpgradeConfig(true, "")
+			next := time.Now().UTC().Add(3 * time.Hour)
+			upgradeConfig.Spec.Schedule = &upgradev1alpha1.RecurringSchedule{Cron: fmt.Sprintf("0 %d * * *", next.Hour())}
+			result := s.IsReadyToUpgrade(upgradeConfig, 60*time.Minute, 0)
+			Expect(result.IsReady).To(BeFalse())
+			Expect(result.IsBreached).To(BeFalse())
+			Expect(result.TimeUntilUpgrade).To(BeNumerically(">", 2*time.Hour))
+			Expect(result.TimeUntilUpgrade).To(BeNumerically("<=", 3*time.Hour))
+		})
+		It("should bound the offset to windows shorter than it", func() {
+			s := &scheduler{}
+			upgradeConfig = testUpgradeConfig(true, "")
+			upgradeConfig.Spec.Schedule = &upgradev1alpha1.RecurringSchedule{Cron: "* * * * *", WindowLength: 60}
+			result := s.IsReadyToUpgrade(upgradeConfig, 5*time.Minute, 90*time.Minute)
+			Expect(result.IsReady).To(BeTrue())
+			Expect(result.IsBreached).To(BeFalse())
+		})
+		It("should not be ready to upgrade if the schedule cannot be evaluated", func() {
+			s := &scheduler{}
+			upgradeConfig = testUpgradeConfig(true, "")
+			upgradeConfig.Spec.Schedule = &upgradev1alpha1.RecurringSchedule{Cron: "not a cron"}
+			result := s.IsReadyToUpgrade(upgradeConfig, 60*time.Minute, 0)
+			Expect(result.IsReady).To(BeFalse())
+		})
+	})
+
+	Context("Recurring windows", func() {
+		var schedule upgradev1alpha1.RecurringSchedule
+		BeforeEach(func() {
+			schedule = upgradev1alpha1.RecurringSchedule{Cron: "0 2 * * 2", TimeZone: "Europe/Berlin", WindowLength: 90}
+		})
+		It("should return the open window in the schedule's time zone", func() {
+			at := time.Date(2021, time.June, 1, 0, 30, 0, 0, time.UTC)
+			start, end, err := GetRecurringWindow(schedule, at, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(start.Equal(time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC))).To(BeTrue())
+			Expect(end.Equal(time.Date(2021, time.June, 1, 1, 30, 0, 0, time.UTC))).To(BeTrue())
+		})
+		It("should return the next window once the open window closes", func() {
+			at := time.Date(2021, time.June, 1, 1, 30, 0, 0, time.UTC)
+			start, _, err := GetRecurringWindow(schedule, at, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(start.Equal(time.Date(2021, time.June, 8, 0, 0, 0, 0, time.UTC))).To(BeTrue())
+		})
+		It("should default the window length to the timeout", func() {
+			schedule.WindowLength = 0
+			at := time.Date(2021, time.June, 1, 0, 30, 0, 0, time.UTC)
+			_, end, err := GetRecurringWindow(schedule, at, 45*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(end.Equal(time.Date(2021, time.June, 1, 0, 45, 0, 0, time.UTC))).To(BeTrue())
+		})
+		It("should reject schedules which cannot be evaluated", func() {
+			Expect(ValidateRecurringSchedule(upgradev1alpha1.RecurringSchedule{Cron: "0 2 * *"})).To(HaveOccurred())
+			Expect(ValidateRecurringSchedule(upgradev1alpha1.RecurringSchedule{Cron: "0 2 * * 2", TimeZone: "Not/AZone"})).To(HaveOccurred())
+			Expect(ValidateRecurringSchedule(upgradev1alpha1.RecurringSchedule{Cron: "CRON_TZ=UTC 0 2 * * 2"})).To(HaveOccurred())
+			Expect(ValidateRecurringSchedule(upgradev1alpha1.RecurringSchedule{Cron: "0 2 * * 2", WindowLength: -1})).To(HaveOccurred())
+			Expect(ValidateRecurringSchedule(schedule)).NotTo(HaveOccurred())
+		})
+	})
 })
 
 func testUpgradeConfig(proceed bool, upgradeAt string) *upgradev1alpha1.UpgradeConfig {
@@ -59,7 +59,7 @@ func (ppb *specProviderBuilder) New(client client.Client, builder configmanager.
 		if err != nil {
 			return nil, err
 		}
-		provider, err := localprovider.New(client, cfg.ConfigManager.LocalConfigName)
+		provider, err := localprovider.New(client, cfg.ConfigManager.LocalConfigName, cfg.GetUpgradeWindowTimeOutDuration())
 		if err != nil {
 			return nil, err
 		}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/openshift/managed-upgrade-operator/pkg/alertscope"
 	ac "github.com/openshift/managed-upgrade-operator/pkg/availabilitychecks"
 	"github.com/openshift/managed-upgrade-operator/pkg/drain"
 )
@@ -15,6 +16,7 @@ type aroUpgradeConfig struct {
 	HealthCheck                    healthCheck                       `yaml:"healthCheck"`
 	ExtDependencyAvailabilityCheck ac.ExtDependencyAvailabilityCheck `yaml:"extDependencyAvailabilityChecks"`
 	UpgradeWindow                  upgradeWindow                     `yaml:"upgradeWindow"`
+	AlertScope                     alertscope.AlertScope             `yaml:"alertScope"`
 }
 
 type maintenanceConfig struct {
@@ -66,6 +68,9 @@ func (cfg *aroUpgradeConfig) IsValid() error {
 	if err := cfg.Maintenance.IsValid(); err != nil {
 		return err
 	}
+	if err := cfg.AlertScope.IsValid(); err != nil {
+		return err
+	}
 	if cfg.Scale.TimeOut <= 0 {
 		return fmt.Errorf("Config scale timeOut is invalid")
 	}
@@ -75,6 +80,9 @@ func (cfg *aroUpgradeConfig) IsValid() error {
 	if cfg.NodeDrain.ExpectedNodeDrainTime <= 0 {
 		return fmt.Errorf("Config nodeDrain expectedNodeDrainTime is invalid")
 	}
+	if err := cfg.NodeDrain.IsValid(); err != nil {
+		return err
+	}
 	if cfg.UpgradeWindow.DelayTrigger < 0 {
 		return fmt.Errorf("Config upgrade window delay trigger is invalid")
 	}
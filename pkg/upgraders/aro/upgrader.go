@@ -2,6 +2,7 @@ package aro
 
 import (
 	"github.com/go-logr/logr"
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
@@ -31,14 +32,14 @@ type UpgradeStep func(client.Client, *aroUpgradeConfig, scaler.Scaler, drain.Nod
 type UpgradeStepOrdering []upgradev1alpha1.UpgradeConditionType
 
 // NewClient returns a new aroClusterUpgrader
-func NewClient(c client.Client, cfm configmanager.ConfigManager, mc metrics.Metrics, notifier eventmanager.EventManager) (*aroClusterUpgrader, error) {
+func NewClient(c client.Client, cfm configmanager.ConfigManager, mc metrics.Metrics, notifier eventmanager.EventManager, kubeClient kubernetes.Interface) (*aroClusterUpgrader, error) {
 	cfg := &aroUpgradeConfig{}
 	err := cfm.Into(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	m, err := maintenance.NewBuilder().NewClient(c)
+	m, err := maintenance.NewBuilder().NewClient(c, cfg.AlertScope)
 	if err != nil {
 		return nil, err
 	}
@@ -57,7 +58,7 @@ func NewClient(c client.Client, cfm configmanager.ConfigManager, mc metrics.Metr
 		maintenance:          m,
 		metrics:              mc,
 		scaler:               scaler.NewScaler(),
-		drainstrategyBuilder: drain.NewBuilder(),
+		drainstrategyBuilder: drain.NewBuilder(kubeClient),
 		cvClient:             cv.NewCVClient(c),
 		cfg:                  cfg,
 		machinery:            machinery.NewMachinery(),
@@ -2,8 +2,10 @@ package osd
 
 import (
 	"fmt"
+	"regexp"
 	"time"
 
+	"github.com/openshift/managed-upgrade-operator/pkg/alertscope"
 	ac "github.com/openshift/managed-upgrade-operator/pkg/availabilitychecks"
 	"github.com/openshift/managed-upgrade-operator/pkg/drain"
 )
@@ -15,6 +17,8 @@ type osdUpgradeConfig struct {
 	HealthCheck                    healthCheck                       `yaml:"healthCheck"`
 	ExtDependencyAvailabilityCheck ac.ExtDependencyAvailabilityCheck `yaml:"extDependencyAvailabilityChecks"`
 	UpgradeWindow                  upgradeWindow                     `yaml:"upgradeWindow"`
+	AlertScope                     alertscope.AlertScope             `yaml:"alertScope"`
+	WorkloadAvailabilityCheck      workloadAvailabilityCheck         `yaml:"workloadAvailabilityCheck"`
 }
 
 type maintenanceConfig struct {
@@ -61,12 +65,88 @@ type scaleConfig struct {
 type healthCheck struct {
 	IgnoredCriticals  []string `yaml:"ignoredCriticals"`
 	IgnoredNamespaces []string `yaml:"ignoredNamespaces"`
+	// Time in minutes a critical alert must have been active before it fails the health check
+	AlertFiringDuration int `yaml:"alertFiringDuration"`
+	// Number of times a critical alert must have fired within the alertFiringWindow before it fails the health check
+	AlertFiringOccurrences int `yaml:"alertFiringOccurrences"`
+	// Time in minutes over which alertFiringOccurrences are counted
+	AlertFiringWindow int `yaml:"alertFiringWindow"`
+	// Check for PodDisruptionBudgets which will block node drains
+	BlockingPDBs blockingPDBCheck `yaml:"blockingPDBs"`
+}
+
+const (
+	// blockingPDBActionWarn records blocking PodDisruptionBudgets and warns of them when the upgrade starts
+	blockingPDBActionWarn = "Warn"
+	// blockingPDBActionFail fails the health check while blocking PodDisruptionBudgets exist
+	blockingPDBActionFail = "Fail"
+)
+
+type blockingPDBCheck struct {
+	// Warn or Fail, the check is disabled if unset
+	Action string `yaml:"action"`
+	// Regular expressions matching namespaces whose PodDisruptionBudgets are not checked
+	ExcludedNamespaces []string `yaml:"excludedNamespaces"`
+}
+
+func (cfg *healthCheck) IsValid() error {
+	if cfg.AlertFiringDuration < 0 {
+		return fmt.Errorf("config healthCheck alertFiringDuration is invalid")
+	}
+	if cfg.AlertFiringOccurrences < 0 {
+		return fmt.Errorf("config healthCheck alertFiringOccurrences is invalid")
+	}
+	if cfg.AlertFiringOccurrences > 0 && cfg.AlertFiringWindow <= 0 {
+		return fmt.Errorf("config healthCheck alertFiringWindow is invalid")
+	}
+	switch cfg.BlockingPDBs.Action {
+	case "", blockingPDBActionWarn, blockingPDBActionFail:
+	default:
+		return fmt.Errorf("config healthCheck blockingPDBs action %s is invalid", cfg.BlockingPDBs.Action)
+	}
+	for _, ns := range cfg.BlockingPDBs.ExcludedNamespaces {
+		if _, err := regexp.Compile(ns); err != nil {
+			return fmt.Errorf("config healthCheck blockingPDBs excludedNamespaces are invalid: %v", err)
+		}
+	}
+	return nil
+}
+
+// IsDebounced returns true if critical alerts must persist or recur before failing the health check
+func (cfg *healthCheck) IsDebounced() bool {
+	return cfg.AlertFiringDuration > 0 || cfg.AlertFiringOccurrences > 0
+}
+
+func (cfg *healthCheck) GetAlertFiringDuration() time.Duration {
+	return time.Duration(cfg.AlertFiringDuration) * time.Minute
+}
+
+func (cfg *healthCheck) GetAlertFiringWindow() time.Duration {
+	return time.Duration(cfg.AlertFiringWindow) * time.Minute
+}
+
+type workloadAvailabilityCheck struct {
+	Enabled bool `yaml:"enabled"`
+	// Namespaces whose workloads are checked. All non-platform namespaces are checked if none are given.
+	Namespaces []string `yaml:"namespaces"`
+	// Time in minutes after the workers are upgraded that workloads are given to recover
+	TimeOut int `yaml:"timeOut" default:"10"`
+}
+
+func (cfg *workloadAvailabilityCheck) GetTimeOutDuration() time.Duration {
+	return time.Duration(cfg.TimeOut) * time.Minute
 }
 
 func (cfg *osdUpgradeConfig) IsValid() error {
 	if err := cfg.Maintenance.IsValid(); err != nil {
 		return err
 	}
+	if err := cfg.AlertScope.IsValid(); err != nil {
+		return err
+	}
+	if err := cfg.HealthCheck.IsValid(); err != nil {
+		return err
+	}
 	if cfg.Scale.TimeOut <= 0 {
 		return fmt.Errorf("config scale timeOut is invalid")
 	}
@@ -76,12 +156,18 @@ func (cfg *osdUpgradeConfig) IsValid() error {
 	if cfg.NodeDrain.ExpectedNodeDrainTime <= 0 {
 		return fmt.Errorf("config nodeDrain expectedNodeDrainTime is invalid")
 	}
+	if err := cfg.NodeDrain.IsValid(); err != nil {
+		return err
+	}
 	if cfg.UpgradeWindow.DelayTrigger < 0 {
 		return fmt.Errorf("config upgrade window delay trigger is invalid")
 	}
 	if cfg.UpgradeWindow.TimeOut < 0 {
 		return fmt.Errorf("config upgrade window time out is invalid")
 	}
+	if cfg.WorkloadAvailabilityCheck.TimeOut < 0 {
+		return fmt.Errorf("config workload availability check time out is invalid")
+	}
 	if len(cfg.ExtDependencyAvailabilityCheck.HTTP.URLS) > 0 && cfg.ExtDependencyAvailabilityCheck.HTTP.Timeout <= 0 || cfg.ExtDependencyAvailabilityCheck.HTTP.Timeout > 60 {
 		return fmt.Errorf("config HTTP timeout is invalid (Requires int between 1 - 60 inclusive)")
 	}
@@ -1,12 +1,15 @@
 package osd
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
@@ -20,6 +23,13 @@ import (
 	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
 	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
 	"github.com/openshift/managed-upgrade-operator/pkg/scaler"
+	"github.com/openshift/managed-upgrade-operator/pkg/scheduler"
+	"github.com/openshift/managed-upgrade-operator/pkg/workloads"
+)
+
+const (
+	// alertHistoryStep is the resolution at which the firing history of alerts is sampled
+	alertHistoryStep = 30 * time.Second
 )
 
 var (
@@ -29,6 +39,7 @@ var (
 		upgradev1alpha1.UpgradeDelayedCheck,
 		upgradev1alpha1.UpgradePreHealthCheck,
 		upgradev1alpha1.ExtDepAvailabilityCheck,
+		upgradev1alpha1.WorkloadAvailabilitySnapshot,
 		upgradev1alpha1.UpgradeScaleUpExtraNodes,
 		upgradev1alpha1.ControlPlaneMaintWindow,
 		upgradev1alpha1.CommenceUpgrade,
@@ -36,6 +47,7 @@ var (
 		upgradev1alpha1.RemoveControlPlaneMaintWindow,
 		upgradev1alpha1.WorkersMaintWindow,
 		upgradev1alpha1.AllWorkerNodesUpgraded,
+		upgradev1alpha1.WorkloadAvailabilityCheck,
 		upgradev1alpha1.RemoveExtraScaledNodes,
 		upgradev1alpha1.RemoveMaintWindow,
 		upgradev1alpha1.PostClusterHealthCheck,
@@ -53,14 +65,14 @@ type UpgradeStep func(client.Client, *osdUpgradeConfig, scaler.Scaler, drain.Nod
 type UpgradeStepOrdering []upgradev1alpha1.UpgradeConditionType
 
 // NewClient returns a new osdClusterUpgrader
-func NewClient(c client.Client, cfm configmanager.ConfigManager, mc metrics.Metrics, notifier eventmanager.EventManager) (*osdClusterUpgrader, error) {
+func NewClient(c client.Client, cfm configmanager.ConfigManager, mc metrics.Metrics, notifier eventmanager.EventManager, kubeClient kubernetes.Interface) (*osdClusterUpgrader, error) {
 	cfg := &osdUpgradeConfig{}
 	err := cfm.Into(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	m, err := maintenance.NewBuilder().NewClient(c)
+	m, err := maintenance.NewBuilder().NewClient(c, cfg.AlertScope)
 	if err != nil {
 		return nil, err
 	}
@@ -75,6 +87,7 @@ func NewClient(c client.Client, cfm configmanager.ConfigManager, mc metrics.Metr
 		upgradev1alpha1.UpgradeDelayedCheck:           UpgradeDelayedCheck,
 		upgradev1alpha1.UpgradePreHealthCheck:         PreClusterHealthCheck,
 		upgradev1alpha1.ExtDepAvailabilityCheck:       ExternalDependencyAvailabilityCheck,
+		upgradev1alpha1.WorkloadAvailabilitySnapshot:  SnapshotWorkloadAvailability,
 		upgradev1alpha1.UpgradeScaleUpExtraNodes:      EnsureExtraUpgradeWorkers,
 		upgradev1alpha1.ControlPlaneMaintWindow:       CreateControlPlaneMaintWindow,
 		upgradev1alpha1.CommenceUpgrade:               CommenceUpgrade,
@@ -82,6 +95,7 @@ func NewClient(c client.Client, cfm configmanager.ConfigManager, mc metrics.Metr
 		upgradev1alpha1.RemoveControlPlaneMaintWindow: RemoveControlPlaneMaintWindow,
 		upgradev1alpha1.WorkersMaintWindow:            CreateWorkerMaintWindow,
 		upgradev1alpha1.AllWorkerNodesUpgraded:        AllWorkersUpgraded,
+		upgradev1alpha1.WorkloadAvailabilityCheck:     CheckWorkloadAvailability,
 		upgradev1alpha1.RemoveExtraScaledNodes:        RemoveExtraScaledNodes,
 		upgradev1alpha1.RemoveMaintWindow:             RemoveMaintWindow,
 		upgradev1alpha1.PostClusterHealthCheck:        PostClusterHealthCheck,
@@ -95,7 +109,7 @@ func NewClient(c client.Client, cfm configmanager.ConfigManager, mc metrics.Metr
 		maintenance:          m,
 		metrics:              mc,
 		scaler:               scaler.NewScaler(),
-		drainstrategyBuilder: drain.NewBuilder(),
+		drainstrategyBuilder: drain.NewBuilder(kubeClient),
 		cvClient:             cv.NewCVClient(c),
 		cfg:                  cfg,
 		machinery:            machinery.NewMachinery(),
@@ -138,6 +152,24 @@ func PreClusterHealthCheck(c client.Client, cfg *osdUpgradeConfig, scaler scaler
 		return false, err
 	}
 
+	ok, err = performNodeHealthCheck(c, machinery, logger)
+	if err != nil || !ok {
+		metricsClient.UpdateMetricClusterCheckFailed(upgradeConfig.Name)
+		return false, err
+	}
+
+	ok, err = performUpgradeableCheck(cvClient, upgradeConfig, logger)
+	if err != nil || !ok {
+		metricsClient.UpdateMetricClusterCheckFailed(upgradeConfig.Name)
+		return false, err
+	}
+
+	ok, err = performBlockingPDBCheck(c, cfg, upgradeConfig, logger)
+	if err != nil || !ok {
+		metricsClient.UpdateMetricClusterCheckFailed(upgradeConfig.Name)
+		return false, err
+	}
+
 	metricsClient.UpdateMetricClusterCheckSucceeded(upgradeConfig.Name)
 	return true, nil
 }
@@ -204,6 +236,86 @@ func ExternalDependencyAvailabilityCheck(c client.Client, cfg *osdUpgradeConfig,
 	return true, nil
 }
 
+// SnapshotWorkloadAvailability records the availability of customer workloads before the upgrade commences
+func SnapshotWorkloadAvailability(c client.Client, cfg *osdUpgradeConfig, s scaler.Scaler, dsb drain.NodeDrainStrategyBuilder, metricsClient metrics.Metrics, m maintenance.Maintenance, cvClient cv.ClusterVersion, nc eventmanager.EventManager, upgradeConfig *upgradev1alpha1.UpgradeConfig, machinery machinery.Machinery, availabilityCheckers ac.AvailabilityCheckers, logger logr.Logger) (bool, error) {
+	if !cfg.WorkloadAvailabilityCheck.Enabled {
+		logger.Info("Workload availability check is disabled. Skipping.")
+		return true, nil
+	}
+
+	upgradeCommenced, err := cvClient.HasUpgradeCommenced(upgradeConfig)
+	if err != nil {
+		return false, err
+	}
+	desired := upgradeConfig.Spec.Desired
+	if upgradeCommenced {
+		logger.Info(fmt.Sprintf("ClusterVersion is already set to Channel %s Version %s, skipping %s", desired.Channel, desired.Version, upgradev1alpha1.WorkloadAvailabilitySnapshot))
+		return true, nil
+	}
+
+	availability, err := workloads.GetAvailability(c, cfg.WorkloadAvailabilityCheck.Namespaces)
+	if err != nil {
+		return false, err
+	}
+
+	h := upgradeConfig.Status.History.GetHistory(desired.Version)
+	if h == nil {
+		return false, nil
+	}
+	snapshot, truncated := workloads.GetSnapshot(availability)
+	if truncated {
+		logger.Info(fmt.Sprintf("Only recording the availability of the first %d of %d workloads", len(snapshot), len(availability)))
+	}
+	h.WorkloadAvailability = snapshot
+	upgradeConfig.Status.History.SetHistory(*h)
+	logger.Info(fmt.Sprintf("Recorded the availability of %d workloads", len(snapshot)))
+
+	return true, nil
+}
+
+// CheckWorkloadAvailability flags customer workloads whose availability dropped over the course of the upgrade
+func CheckWorkloadAvailability(c client.Client, cfg *osdUpgradeConfig, s scaler.Scaler, dsb drain.NodeDrainStrategyBuilder, metricsClient metrics.Metrics, m maintenance.Maintenance, cvClient cv.ClusterVersion, nc eventmanager.EventManager, upgradeConfig *upgradev1alpha1.UpgradeConfig, machinery machinery.Machinery, availabilityCheckers ac.AvailabilityCheckers, logger logr.Logger) (bool, error) {
+	if !cfg.WorkloadAvailabilityCheck.Enabled {
+		logger.Info("Workload availability check is disabled. Skipping.")
+		return true, nil
+	}
+
+	h := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+	if h == nil || len(h.WorkloadAvailability) == 0 {
+		logger.Info("No workload availability was recorded before the upgrade. Skipping.")
+		return true, nil
+	}
+
+	availability, err := workloads.GetAvailability(c, cfg.WorkloadAvailabilityCheck.Namespaces)
+	if err != nil {
+		return false, err
+	}
+
+	degraded := workloads.FindDegraded(h.WorkloadAvailability, availability)
+	if len(degraded) > 0 {
+		// Give workloads rescheduled from the last upgraded workers time to become ready again.
+		// The worker completion time is recorded by the machineconfigpool controller, but only if it saw the
+		// worker upgrade start. The workers have all upgraded by this step, so otherwise record it here.
+		if h.WorkerCompleteTime == nil {
+			logger.Info(fmt.Sprintf("Waiting for workloads to recover after the worker upgrade: %s", strings.Join(degraded, ", ")))
+			h.WorkerCompleteTime = &metav1.Time{Time: time.Now()}
+			upgradeConfig.Status.History.SetHistory(*h)
+			return false, nil
+		}
+		timeOut := cfg.WorkloadAvailabilityCheck.GetTimeOutDuration()
+		if time.Now().Before(h.WorkerCompleteTime.Add(timeOut)) {
+			logger.Info(fmt.Sprintf("Waiting for workloads to recover: %s", strings.Join(degraded, ", ")))
+			return false, nil
+		}
+		logger.Info(fmt.Sprintf("Workloads with reduced availability after upgrade: %s", strings.Join(degraded, ", ")))
+	}
+
+	h.DegradedWorkloads = degraded
+	upgradeConfig.Status.History.SetHistory(*h)
+
+	return true, nil
+}
+
 // CommenceUpgrade will update the clusterversion object to apply the desired version to trigger real OCP upgrade
 func CommenceUpgrade(c client.Client, cfg *osdUpgradeConfig, scaler scaler.Scaler, dsb drain.NodeDrainStrategyBuilder, metricsClient metrics.Metrics, m maintenance.Maintenance, cvClient cv.ClusterVersion, nc eventmanager.EventManager, upgradeConfig *upgradev1alpha1.UpgradeConfig, machinery machinery.Machinery, availabilityCheckers ac.AvailabilityCheckers, logger logr.Logger) (bool, error) {
 
@@ -285,6 +397,14 @@ func CreateWorkerMaintWindow(c client.Client, cfg *osdUpgradeConfig, scaler scal
 	totalWorkerMaintenanceDuration := waitTimePeriod + actionTimePeriod
 
 	endTime := time.Now().Add(totalWorkerMaintenanceDuration)
+	// An upgrade window's worker end takes precedence over the estimate
+	workerEnd, ok, err := scheduler.GetWorkerWindowEnd(upgradeConfig)
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		endTime = workerEnd
+	}
 	logger.Info(fmt.Sprintf("Creating worker node maintenance for %d remaining nodes if no previous silence, ending at %v", pendingWorkerCount, endTime))
 	err = m.SetWorker(endTime, upgradeConfig.Spec.Desired.Version, pendingWorkerCount)
 	if err != nil {
@@ -403,6 +523,25 @@ func ControlPlaneUpgraded(c client.Client, cfg *osdUpgradeConfig, scaler scaler.
 
 // SendStartedNotification sends a notification on upgrade commencement
 func SendStartedNotification(c client.Client, cfg *osdUpgradeConfig, scaler scaler.Scaler, dsb drain.NodeDrainStrategyBuilder, metricsClient metrics.Metrics, m maintenance.Maintenance, cvClient cv.ClusterVersion, nc eventmanager.EventManager, upgradeConfig *upgradev1alpha1.UpgradeConfig, machinery machinery.Machinery, availabilityCheckers ac.AvailabilityCheckers, logger logr.Logger) (bool, error) {
+	// The notification is described from the stored UpgradeConfig, so record any PodDisruptionBudgets blocking
+	// node drains ahead of the pre-upgrade health check for it to warn of them
+	if cfg.HealthCheck.BlockingPDBs.Action != "" {
+		notified, err := metricsClient.IsMetricNotificationEventSentSet(upgradeConfig.Name, string(notifier.StateStarted), upgradeConfig.Spec.Desired.Version)
+		if err != nil {
+			return false, err
+		}
+		if !notified {
+			_, err = recordBlockingPdbs(c, cfg, upgradeConfig)
+			if err != nil {
+				return false, err
+			}
+			err = c.Status().Update(context.TODO(), upgradeConfig)
+			if err != nil {
+				return false, err
+			}
+		}
+	}
+
 	err := nc.Notify(notifier.StateStarted)
 	if err != nil {
 		return false, err
@@ -469,6 +608,14 @@ func shouldFailUpgrade(cvClient cv.ClusterVersion, cfg *osdUpgradeConfig, upgrad
 	startTime := h.StartTime.Time
 
 	upgradeWindowDuration := cfg.UpgradeWindow.GetUpgradeWindowTimeOutDuration()
+	// An upgrade with an explicit or recurring window fails once the window it started in closes
+	if !startTime.IsZero() && (upgradeConfig.Spec.Window != nil || upgradeConfig.Spec.Schedule != nil) {
+		_, windowEnd, err := scheduler.GetUpgradeWindow(upgradeConfig, startTime, upgradeWindowDuration)
+		if err != nil {
+			return false, err
+		}
+		return time.Now().After(windowEnd), nil
+	}
 	if !startTime.IsZero() && upgradeWindowDuration > 0 && time.Now().After(startTime.Add(upgradeWindowDuration)) {
 		return true, nil
 	}
@@ -554,26 +701,31 @@ func performClusterHealthCheck(c client.Client, metricsClient metrics.Metrics, c
 		icQuery = `,alertname!="` + strings.Join(ic, `",alertname!="`) + `"`
 	}
 
-	ignoredNamespace := cfg.HealthCheck.IgnoredNamespaces
-	ignoredNamespaceQuery := ""
-	if len(ignoredNamespace) > 0 {
-		ignoredNamespaceQuery = `,namespace!="` + strings.Join(ignoredNamespace, `",namespace!="`) + `"`
-	}
-
-	healthCheckQuery := `ALERTS{alertstate="firing",severity="critical",namespace=~"^openshift.*|^kube-.*|^default$"` + ignoredNamespaceQuery + icQuery + "}"
+	// Namespaces ignored by the health check are excluded from its alert scope
+	scope := cfg.AlertScope.WithExcludedNamespaces(cfg.HealthCheck.IgnoredNamespaces...)
+	selectors := scope.CriticalAlertSelectors() + icQuery
+	healthCheckQuery := `ALERTS{alertstate="firing",` + selectors + "}"
 
 	alerts, err := metricsClient.Query(healthCheckQuery)
 	if err != nil {
 		return false, fmt.Errorf("unable to query critical alerts: %s", err)
 	}
 
-	alertCount := len(alerts.Data.Result)
+	firing := alerts.Data.Result
+	if len(firing) > 0 && cfg.HealthCheck.IsDebounced() {
+		firing, err = debounceAlerts(metricsClient, firing, selectors, &cfg.HealthCheck)
+		if err != nil {
+			return false, fmt.Errorf("unable to query critical alert history: %s", err)
+		}
+	}
+
+	alertCount := len(firing)
 
 	if alertCount > 0 {
 		alert := []string{}
 		uniqueAlerts := make(map[string]bool)
 
-		for _, r := range alerts.Data.Result {
+		for _, r := range firing {
 			a := r.Metric["alertname"]
 
 			if uniqueAlerts[a] {
@@ -600,6 +752,166 @@ func performClusterHealthCheck(c client.Client, metricsClient metrics.Metrics, c
 	return true, nil
 }
 
+// debounceAlerts filters the firing alerts down to those which have been active for the configured
+// duration, or which have fired the configured number of times within the configured window. An alert
+// which has fired continuously across the whole window is always kept, as it is persistent rather than
+// flapping, however few times it has fired.
+func debounceAlerts(metricsClient metrics.Metrics, firing []metrics.AlertResult, selectors string, cfg *healthCheck) ([]metrics.AlertResult, error) {
+	persistent := make(map[string]bool)
+
+	if cfg.AlertFiringDuration > 0 {
+		// ALERTS_FOR_STATE holds the time at which each alert became active
+		query := fmt.Sprintf("ALERTS_FOR_STATE{%s} < time() - %d", selectors, int64(cfg.GetAlertFiringDuration().Seconds()))
+		active, err := metricsClient.Query(query)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range active.Data.Result {
+			persistent[r.Metric["alertname"]] = true
+		}
+	}
+
+	if cfg.AlertFiringOccurrences > 0 {
+		now := time.Now()
+		start := now.Add(-cfg.GetAlertFiringWindow())
+		query := `ALERTS{alertstate="firing",` + selectors + "}"
+		history, err := metricsClient.QueryRange(query, start, now, alertHistoryStep)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range history.Data.Result {
+			sampleTimes := r.GetSampleTimes()
+			if countFiringOccurrences(sampleTimes, alertHistoryStep) >= cfg.AlertFiringOccurrences ||
+				isFiringThroughout(sampleTimes, start, now, alertHistoryStep) {
+				persistent[r.Metric["alertname"]] = true
+			}
+		}
+	}
+
+	debounced := []metrics.AlertResult{}
+	for _, r := range firing {
+		if persistent[r.Metric["alertname"]] {
+			debounced = append(debounced, r)
+		}
+	}
+	return debounced, nil
+}
+
+// countFiringOccurrences counts the separate periods an alert was firing from its sample times,
+// treating any gap longer than the sampling step as the alert having resolved
+func countFiringOccurrences(sampleTimes []time.Time, step time.Duration) int {
+	occurrences := 0
+	var previous time.Time
+	for _, t := range sampleTimes {
+		if previous.IsZero() || t.Sub(previous) > step {
+			occurrences++
+		}
+		previous = t
+	}
+	return occurrences
+}
+
+// isFiringThroughout returns true if an alert's sample times show it firing without resolving from the
+// start to the end of the queried range
+func isFiringThroughout(sampleTimes []time.Time, start time.Time, end time.Time, step time.Duration) bool {
+	if len(sampleTimes) == 0 || countFiringOccurrences(sampleTimes, step) != 1 {
+		return false
+	}
+	return !sampleTimes[0].After(start.Add(step)) && !sampleTimes[len(sampleTimes)-1].Before(end.Add(-step))
+}
+
+// check the nodes and machine config pools of the cluster and report problems
+// * nodes that are NotReady, under memory/disk pressure or unexpectedly unschedulable
+// * degraded machine config pools
+func performNodeHealthCheck(c client.Client, machinery machinery.Machinery, logger logr.Logger) (bool, error) {
+	nodeResult, err := machinery.HasUnhealthyNodes(c)
+	if err != nil {
+		return false, fmt.Errorf("unable to check node health: %s", err)
+	}
+	if len(nodeResult.Unhealthy) > 0 {
+		logger.Info(fmt.Sprintf("Unhealthy nodes: %s. Cannot continue upgrade", strings.Join(nodeResult.Unhealthy, ", ")))
+		return false, fmt.Errorf("unhealthy nodes: %s", strings.Join(nodeResult.Unhealthy, ", "))
+	}
+
+	poolResult, err := machinery.HasDegradedPools(c)
+	if err != nil {
+		return false, fmt.Errorf("unable to check machine config pools: %s", err)
+	}
+	if len(poolResult.Degraded) > 0 {
+		logger.Info(fmt.Sprintf("Degraded machine config pools: %s. Cannot continue upgrade", strings.Join(poolResult.Degraded, ", ")))
+		return false, fmt.Errorf("degraded machine config pools: %s", strings.Join(poolResult.Degraded, ", "))
+	}
+
+	return true, nil
+}
+
+// performUpgradeableCheck fails a minor version upgrade if any ClusterOperator reports Upgradeable=False,
+// as the CVO would otherwise refuse the upgrade once it has been requested.
+func performUpgradeableCheck(cvClient cv.ClusterVersion, upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (bool, error) {
+	clusterVersion, err := cvClient.GetClusterVersion()
+	if err != nil {
+		return false, err
+	}
+
+	isMinorUpgrade, err := cv.IsMinorUpgrade(clusterVersion, upgradeConfig.Spec.Desired.Version)
+	if err != nil {
+		return false, err
+	}
+	if !isMinorUpgrade {
+		return true, nil
+	}
+
+	result, err := cvClient.HasNonUpgradeableOperators()
+	if err != nil {
+		return false, fmt.Errorf("unable to check operator upgradeability: %s", err)
+	}
+	if len(result.NonUpgradeable) > 0 {
+		logger.Info(fmt.Sprintf("Operators not upgradeable: %s. Cannot continue minor upgrade", strings.Join(result.NonUpgradeable, ", ")))
+		return false, fmt.Errorf("operators blocking minor upgrade: %s", strings.Join(result.NonUpgradeable, ", "))
+	}
+
+	return true, nil
+}
+
+// performBlockingPDBCheck records the PodDisruptionBudgets which will block node drains in the upgrade history,
+// failing the check if configured to do so
+func performBlockingPDBCheck(c client.Client, cfg *osdUpgradeConfig, upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (bool, error) {
+	pdbCheck := cfg.HealthCheck.BlockingPDBs
+	if pdbCheck.Action == "" {
+		return true, nil
+	}
+
+	blocking, err := recordBlockingPdbs(c, cfg, upgradeConfig)
+	if err != nil {
+		return false, err
+	}
+
+	if len(blocking) > 0 {
+		if pdbCheck.Action == blockingPDBActionFail {
+			logger.Info(fmt.Sprintf("PodDisruptionBudgets blocking node drains: %s. Cannot continue upgrade", strings.Join(blocking, ", ")))
+			return false, fmt.Errorf("PodDisruptionBudgets blocking node drains: %s", strings.Join(blocking, ", "))
+		}
+		logger.Info(fmt.Sprintf("PodDisruptionBudgets blocking node drains: %s. Node drains will be forced once they time out", strings.Join(blocking, ", ")))
+	}
+
+	return true, nil
+}
+
+// recordBlockingPdbs records the PodDisruptionBudgets which will block node drains in the upgrade history
+func recordBlockingPdbs(c client.Client, cfg *osdUpgradeConfig, upgradeConfig *upgradev1alpha1.UpgradeConfig) ([]string, error) {
+	blocking, err := drain.GetBlockingPdbs(c, cfg.HealthCheck.BlockingPDBs.ExcludedNamespaces)
+	if err != nil {
+		return nil, fmt.Errorf("unable to check PodDisruptionBudgets: %s", err)
+	}
+
+	h := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+	if h != nil {
+		h.BlockingPodDisruptionBudgets = blocking
+		upgradeConfig.Status.History.SetHistory(*h)
+	}
+	return blocking, nil
+}
+
 func newUpgradeCondition(reason, msg string, conditionType upgradev1alpha1.UpgradeConditionType, s corev1.ConditionStatus) *upgradev1alpha1.UpgradeCondition {
 	return &upgradev1alpha1.UpgradeCondition{
 		Type:    conditionType,
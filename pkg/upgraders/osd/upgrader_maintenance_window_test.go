@@ -2,6 +2,7 @@ package osd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/golang/mock/gomock"
@@ -119,6 +120,19 @@ var _ = Describe("ClusterUpgrader maintenance window tests", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(result).To(BeTrue())
 		})
+		It("Ends the maintenance window at the upgrade window's worker end when specified", func() {
+			workerEnd := time.Date(2021, time.June, 1, 6, 0, 0, 0, time.UTC)
+			upgradeConfig.Spec.Window = &upgradev1alpha1.UpgradeWindow{
+				Start:     "2021-06-01T00:00:00Z",
+				End:       "2021-06-01T02:00:00Z",
+				WorkerEnd: workerEnd.Format(time.RFC3339),
+			}
+			mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true, MachineCount: 4, UpdatedCount: 2}, nil)
+			mockMaintClient.EXPECT().SetWorker(workerEnd, upgradeConfig.Spec.Desired.Version, int32(2))
+			result, err := CreateWorkerMaintWindow(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
 		It("Indicates when creating the maintenance window has failed", func() {
 			fakeError := fmt.Errorf("fake error")
 			mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true, MachineCount: 4, UpdatedCount: 2}, nil)
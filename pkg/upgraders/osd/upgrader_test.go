@@ -1,6 +1,7 @@
 package osd
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -9,7 +10,9 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	configv1 "github.com/openshift/api/config/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -348,6 +351,94 @@ var _ = Describe("ClusterUpgrader", func() {
 		})
 	})
 
+	Context("When running the workload availability phases", func() {
+		var deployments *appsv1.DeploymentList
+		BeforeEach(func() {
+			config.WorkloadAvailabilityCheck = workloadAvailabilityCheck{Enabled: true, TimeOut: 10}
+			upgradeConfig.Status.History = []upgradev1alpha1.UpgradeHistory{
+				{
+					Version: upgradeConfig.Spec.Desired.Version,
+					Phase:   upgradev1alpha1.UpgradePhaseUpgrading,
+				},
+			}
+			replicas := int32(2)
+			deployments = &appsv1.DeploymentList{
+				Items: []appsv1.Deployment{
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "customer", Name: "web"},
+						Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+						Status:     appsv1.DeploymentStatus{ReadyReplicas: 1},
+					},
+				},
+			}
+		})
+		It("will not record workload availability if the check is disabled", func() {
+			config.WorkloadAvailabilityCheck.Enabled = false
+			result, err := SnapshotWorkloadAvailability(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+		It("will record workload availability before the upgrade commences", func() {
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *deployments).Return(nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+			)
+			result, err := SnapshotWorkloadAvailability(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+			h := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+			Expect(h.WorkloadAvailability).To(HaveLen(1))
+			Expect(h.WorkloadAvailability[0].ReadyReplicas).To(Equal(int32(1)))
+		})
+		It("will not record workload availability if the upgrade has commenced", func() {
+			mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(true, nil)
+			result, err := SnapshotWorkloadAvailability(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+		Context("When workload availability was recorded before the upgrade", func() {
+			BeforeEach(func() {
+				upgradeConfig.Status.History[0].WorkloadAvailability = []upgradev1alpha1.WorkloadAvailability{
+					{Kind: "Deployment", Namespace: "customer", Name: "web", ReadyReplicas: 2},
+				}
+			})
+			It("will wait for workloads to recover after the workers are upgraded", func() {
+				upgradeConfig.Status.History[0].WorkerCompleteTime = &metav1.Time{Time: time.Now()}
+				gomock.InOrder(
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *deployments).Return(nil),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+				)
+				result, err := CheckWorkloadAvailability(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeFalse())
+			})
+			It("will record the worker upgrade completion if it has not been, and wait from then", func() {
+				gomock.InOrder(
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *deployments).Return(nil),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+				)
+				result, err := CheckWorkloadAvailability(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeFalse())
+				h := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+				Expect(h.WorkerCompleteTime).NotTo(BeNil())
+			})
+			It("will flag workloads which have not recovered", func() {
+				upgradeConfig.Status.History[0].WorkerCompleteTime = &metav1.Time{Time: time.Now().Add(-30 * time.Minute)}
+				gomock.InOrder(
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *deployments).Return(nil),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+				)
+				result, err := CheckWorkloadAvailability(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeTrue())
+				h := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+				Expect(h.DegradedWorkloads).To(ConsistOf("Deployment customer/web (ready 1/2)"))
+			})
+		})
+	})
+
 	Context("When running the send-started-notification phase", func() {
 		It("will send the correct notification", func() {
 			gomock.InOrder(
@@ -366,6 +457,47 @@ var _ = Describe("ClusterUpgrader", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(result).To(BeFalse())
 		})
+		Context("When checking for PodDisruptionBudgets blocking node drains", func() {
+			BeforeEach(func() {
+				config.HealthCheck.BlockingPDBs.Action = blockingPDBActionWarn
+				upgradeConfig.Status.History = []upgradev1alpha1.UpgradeHistory{{Version: upgradeConfig.Spec.Desired.Version}}
+			})
+			It("will record the blocking PodDisruptionBudgets before the notification describes them", func() {
+				mockUpdater := mocks.NewMockStatusWriter(mockCtrl)
+				pdbList := policyv1beta1.PodDisruptionBudgetList{
+					Items: []policyv1beta1.PodDisruptionBudget{
+						{
+							ObjectMeta: metav1.ObjectMeta{Namespace: "customer", Name: "web"},
+							Status:     policyv1beta1.PodDisruptionBudgetStatus{ExpectedPods: 1, DisruptionsAllowed: 0},
+						},
+					},
+				}
+				gomock.InOrder(
+					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(upgradeConfig.Name, string(notifier.StateStarted), upgradeConfig.Spec.Desired.Version).Return(false, nil),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, pdbList),
+					mockKubeClient.EXPECT().Status().Return(mockUpdater),
+					mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(ctx context.Context, uc *upgradev1alpha1.UpgradeConfig, opts ...client.UpdateOption) error {
+							h := uc.Status.History.GetHistory(uc.Spec.Desired.Version)
+							Expect(h.BlockingPodDisruptionBudgets).To(ConsistOf("customer/web"))
+							return nil
+						}),
+					mockEMClient.EXPECT().Notify(notifier.StateStarted),
+				)
+				result, err := SendStartedNotification(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeTrue())
+			})
+			It("will not check again once the notification has been sent", func() {
+				gomock.InOrder(
+					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(upgradeConfig.Name, string(notifier.StateStarted), upgradeConfig.Spec.Desired.Version).Return(true, nil),
+					mockEMClient.EXPECT().Notify(notifier.StateStarted),
+				)
+				result, err := SendStartedNotification(mockKubeClient, config, mockScalerClient, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeTrue())
+			})
+		})
 	})
 
 	Context("When running the send-completed-notification phase", func() {
@@ -602,6 +734,59 @@ var _ = Describe("ClusterUpgrader", func() {
 					Expect(err).NotTo(HaveOccurred())
 				})
 			})
+
+			Context("When the upgrade hasn't started before its explicit window closed", func() {
+				BeforeEach(func() {
+					upgradeConfig.Spec.Window = &upgradev1alpha1.UpgradeWindow{
+						Start: time.Now().Add(-2 * time.Hour).Format(time.RFC3339),
+						End:   time.Now().Add(-1 * time.Minute).Format(time.RFC3339),
+					}
+					upgradeConfig.Status.History = []upgradev1alpha1.UpgradeHistory{
+						{
+							Version:   upgradeConfig.Spec.Desired.Version,
+							Phase:     upgradev1alpha1.UpgradePhaseUpgrading,
+							StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+						},
+					}
+				})
+				It("flags the upgrade as failed", func() {
+					gomock.InOrder(
+						mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+						mockScalerClient.EXPECT().EnsureScaleDownNodes(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil),
+						mockEMClient.EXPECT().Notify(notifier.StateFailed),
+						mockMetricsClient.EXPECT().UpdateMetricUpgradeWindowBreached(upgradeConfig.Name),
+						mockMetricsClient.EXPECT().ResetFailureMetrics(),
+					)
+					phase, _, err := cu.UpgradeCluster(upgradeConfig, logger)
+					Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseFailed))
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			Context("When a recurring upgrade hasn't started in the window it began in", func() {
+				BeforeEach(func() {
+					upgradeConfig.Spec.Schedule = &upgradev1alpha1.RecurringSchedule{Cron: "* * * * *", WindowLength: 1}
+					upgradeConfig.Status.History = []upgradev1alpha1.UpgradeHistory{
+						{
+							Version:   upgradeConfig.Spec.Desired.Version,
+							Phase:     upgradev1alpha1.UpgradePhaseUpgrading,
+							StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+						},
+					}
+				})
+				It("flags the upgrade as failed", func() {
+					gomock.InOrder(
+						mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+						mockScalerClient.EXPECT().EnsureScaleDownNodes(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil),
+						mockEMClient.EXPECT().Notify(notifier.StateFailed),
+						mockMetricsClient.EXPECT().UpdateMetricUpgradeWindowBreached(upgradeConfig.Name),
+						mockMetricsClient.EXPECT().ResetFailureMetrics(),
+					)
+					phase, _, err := cu.UpgradeCluster(upgradeConfig, logger)
+					Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseFailed))
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
 		})
 
 	})
@@ -3,14 +3,20 @@ package osd
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/openshift/managed-upgrade-operator/pkg/alertscope"
 	ac "github.com/openshift/managed-upgrade-operator/pkg/availabilitychecks"
 	acMocks "github.com/openshift/managed-upgrade-operator/pkg/availabilitychecks/mocks"
 	"github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
 
 	"github.com/go-logr/logr"
 	"github.com/golang/mock/gomock"
+	configv1 "github.com/openshift/api/config/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
@@ -18,6 +24,7 @@ import (
 	"github.com/openshift/managed-upgrade-operator/pkg/drain"
 	mockDrain "github.com/openshift/managed-upgrade-operator/pkg/drain/mocks"
 	emMocks "github.com/openshift/managed-upgrade-operator/pkg/eventmanager/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
 	mockMachinery "github.com/openshift/managed-upgrade-operator/pkg/machinery/mocks"
 	mockMaintenance "github.com/openshift/managed-upgrade-operator/pkg/maintenance/mocks"
 	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
@@ -41,12 +48,13 @@ var _ = Describe("ClusterUpgrader verification and health tests", func() {
 		mockMaintClient          *mockMaintenance.MockMaintenance
 		mockScaler               *mockScaler.MockScaler
 		mockMetricsClient        *mockMetrics.MockMetrics
-		mockMachinery            *mockMachinery.MockMachinery
+		mockMachineryClient      *mockMachinery.MockMachinery
 		mockCVClient             *cvMocks.MockClusterVersion
 		mockDrainStrategyBuilder *mockDrain.MockNodeDrainStrategyBuilder
 		mockEMClient             *emMocks.MockEventManager
 		mockAC                   *acMocks.MockAvailabilityChecker
 		config                   *osdUpgradeConfig
+		clusterVersion           *configv1.ClusterVersion
 	)
 
 	BeforeEach(func() {
@@ -55,11 +63,20 @@ var _ = Describe("ClusterUpgrader verification and health tests", func() {
 			Namespace: "test-namespace",
 		}
 		upgradeConfig = testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).GetUpgradeConfig()
+		upgradeConfig.Spec.Desired.Version = "4.5.2"
+		clusterVersion = &configv1.ClusterVersion{
+			Status: configv1.ClusterVersionStatus{
+				History: []configv1.UpdateHistory{
+					{State: configv1.CompletedUpdate, Version: "4.5.1", CompletionTime: &metav1.Time{Time: time.Now()}},
+				},
+			},
+		}
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockKubeClient = mocks.NewMockClient(mockCtrl)
 		mockMaintClient = mockMaintenance.NewMockMaintenance(mockCtrl)
 		mockMetricsClient = mockMetrics.NewMockMetrics(mockCtrl)
 		mockCVClient = cvMocks.NewMockClusterVersion(mockCtrl)
+		mockMachineryClient = mockMachinery.NewMockMachinery(mockCtrl)
 		mockDrainStrategyBuilder = mockDrain.NewMockNodeDrainStrategyBuilder(mockCtrl)
 		mockEMClient = emMocks.NewMockEventManager(mockCtrl)
 		mockAC = acMocks.NewMockAvailabilityChecker(mockCtrl)
@@ -103,8 +120,11 @@ var _ = Describe("ClusterUpgrader verification and health tests", func() {
 						return &metrics.AlertResponse{}, nil
 					})
 				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil)
+				mockMachineryClient.EXPECT().HasUnhealthyNodes(gomock.Any()).Return(&machinery.HasUnhealthyNodesResult{Unhealthy: []string{}}, nil)
+				mockMachineryClient.EXPECT().HasDegradedPools(gomock.Any()).Return(&machinery.HasDegradedPoolsResult{Degraded: []string{}}, nil)
+				mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil)
 				mockMetricsClient.EXPECT().UpdateMetricClusterCheckSucceeded(upgradeConfig.Name)
-				result, err := PreClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachinery, []ac.AvailabilityChecker{mockAC}, logger)
+				result, err := PreClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
 				Expect(err).To(Not(HaveOccurred()))
 				Expect(result).To(BeTrue())
 			})
@@ -113,10 +133,13 @@ var _ = Describe("ClusterUpgrader verification and health tests", func() {
 					mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
 					mockMetricsClient.EXPECT().Query(gomock.Any()).Return(alertsResponse, nil),
 					mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+					mockMachineryClient.EXPECT().HasUnhealthyNodes(gomock.Any()).Return(&machinery.HasUnhealthyNodesResult{Unhealthy: []string{}}, nil),
+					mockMachineryClient.EXPECT().HasDegradedPools(gomock.Any()).Return(&machinery.HasDegradedPoolsResult{Degraded: []string{}}, nil),
+					mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
 					mockMetricsClient.EXPECT().UpdateMetricClusterCheckSucceeded(upgradeConfig.Name),
 				)
 				// Pre-upgrade
-				result, err := PreClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachinery, []ac.AvailabilityChecker{mockAC}, logger)
+				result, err := PreClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(result).To(BeTrue())
 			})
@@ -126,20 +149,44 @@ var _ = Describe("ClusterUpgrader verification and health tests", func() {
 					mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
 					mockMetricsClient.EXPECT().UpdateMetricClusterCheckSucceeded(upgradeConfig.Name),
 				)
-				result, err := PostClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachinery, []ac.AvailabilityChecker{mockAC}, logger)
+				result, err := PostClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(result).To(BeTrue())
 			})
+			It("will only consider alerts in the configured alert scope", func() {
+				config.AlertScope = alertscope.AlertScope{
+					Namespaces:         []string{"^openshift-.*"},
+					CriticalSeverities: []string{"critical", "page"},
+				}
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil)
+				mockMetricsClient.EXPECT().Query(gomock.Any()).DoAndReturn(
+					func(query string) (*metrics.AlertResponse, error) {
+						Expect(query).To(ContainSubstring(`severity=~"critical|page"`))
+						Expect(query).To(ContainSubstring(`namespace=~"(^openshift-.*)"`))
+						return &metrics.AlertResponse{}, nil
+					})
+				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil)
+				mockMachineryClient.EXPECT().HasUnhealthyNodes(gomock.Any()).Return(&machinery.HasUnhealthyNodesResult{Unhealthy: []string{}}, nil)
+				mockMachineryClient.EXPECT().HasDegradedPools(gomock.Any()).Return(&machinery.HasDegradedPoolsResult{Degraded: []string{}}, nil)
+				mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil)
+				mockMetricsClient.EXPECT().UpdateMetricClusterCheckSucceeded(upgradeConfig.Name)
+				result, err := PreClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+				Expect(err).To(Not(HaveOccurred()))
+				Expect(result).To(BeTrue())
+			})
 			It("will have ignored alerts in specified namespaces", func() {
 				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil)
 				mockMetricsClient.EXPECT().Query(gomock.Any()).DoAndReturn(
 					func(query string) (*metrics.AlertResponse, error) {
-						Expect(strings.Contains(query, `namespace!="`+config.HealthCheck.IgnoredNamespaces[0]+`"`)).To(BeTrue())
+						Expect(strings.Contains(query, `namespace!~"^`+config.HealthCheck.IgnoredNamespaces[0]+`$"`)).To(BeTrue())
 						return &metrics.AlertResponse{}, nil
 					})
 				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil)
+				mockMachineryClient.EXPECT().HasUnhealthyNodes(gomock.Any()).Return(&machinery.HasUnhealthyNodesResult{Unhealthy: []string{}}, nil)
+				mockMachineryClient.EXPECT().HasDegradedPools(gomock.Any()).Return(&machinery.HasDegradedPoolsResult{Degraded: []string{}}, nil)
+				mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil)
 				mockMetricsClient.EXPECT().UpdateMetricClusterCheckSucceeded(upgradeConfig.Name)
-				result, err := PreClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachinery, []ac.AvailabilityChecker{mockAC}, logger)
+				result, err := PreClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
 				Expect(err).To(Not(HaveOccurred()))
 				Expect(result).To(BeTrue())
 			})
@@ -156,10 +203,13 @@ var _ = Describe("ClusterUpgrader verification and health tests", func() {
 					mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
 					mockMetricsClient.EXPECT().Query(gomock.Any()).Return(alertsResponse, nil),
 					mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+					mockMachineryClient.EXPECT().HasUnhealthyNodes(gomock.Any()).Return(&machinery.HasUnhealthyNodesResult{Unhealthy: []string{}}, nil),
+					mockMachineryClient.EXPECT().HasDegradedPools(gomock.Any()).Return(&machinery.HasDegradedPoolsResult{Degraded: []string{}}, nil),
+					mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
 					mockMetricsClient.EXPECT().UpdateMetricClusterCheckSucceeded(upgradeConfig.Name),
 				)
 				// Pre-upgrade
-				result, err := PreClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachinery, []ac.AvailabilityChecker{mockAC}, logger)
+				result, err := PreClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(result).To(BeTrue())
 			})
@@ -169,7 +219,7 @@ var _ = Describe("ClusterUpgrader verification and health tests", func() {
 					mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
 					mockMetricsClient.EXPECT().UpdateMetricClusterCheckSucceeded(upgradeConfig.Name),
 				)
-				result, err := PostClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachinery, []ac.AvailabilityChecker{mockAC}, logger)
+				result, err := PostClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(result).To(BeTrue())
 			})
@@ -195,7 +245,7 @@ var _ = Describe("ClusterUpgrader verification and health tests", func() {
 					mockMetricsClient.EXPECT().Query(gomock.Any()).Return(alertsResponse, nil),
 					mockMetricsClient.EXPECT().UpdateMetricClusterCheckFailed(upgradeConfig.Name),
 				)
-				result, err := PreClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachinery, []ac.AvailabilityChecker{mockAC}, logger)
+				result, err := PreClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
 				Expect(err).To(HaveOccurred())
 				Expect(result).To(BeFalse())
 			})
@@ -204,7 +254,7 @@ var _ = Describe("ClusterUpgrader verification and health tests", func() {
 					mockMetricsClient.EXPECT().Query(gomock.Any()).Return(alertsResponse, nil),
 					mockMetricsClient.EXPECT().UpdateMetricClusterCheckFailed(upgradeConfig.Name),
 				)
-				result, err := PostClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachinery, []ac.AvailabilityChecker{mockAC}, logger)
+				result, err := PostClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
 				Expect(err).To(HaveOccurred())
 				Expect(result).To(BeFalse())
 			})
@@ -223,7 +273,7 @@ var _ = Describe("ClusterUpgrader verification and health tests", func() {
 					mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{"ClusterOperator"}}, nil),
 					mockMetricsClient.EXPECT().UpdateMetricClusterCheckFailed(upgradeConfig.Name),
 				)
-				result, err := PreClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachinery, []ac.AvailabilityChecker{mockAC}, logger)
+				result, err := PreClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
 				Expect(err).To(HaveOccurred())
 				Expect(result).To(BeFalse())
 			})
@@ -233,10 +283,258 @@ var _ = Describe("ClusterUpgrader verification and health tests", func() {
 					mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{"ClusterOperator"}}, nil),
 					mockMetricsClient.EXPECT().UpdateMetricClusterCheckFailed(upgradeConfig.Name),
 				)
-				result, err := PostClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachinery, []ac.AvailabilityChecker{mockAC}, logger)
+				result, err := PostClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+				Expect(err).To(HaveOccurred())
+				Expect(result).To(BeFalse())
+			})
+		})
+	})
+
+	Context("When critical alerts are debounced", func() {
+		var firingResponse *metrics.AlertResponse
+		BeforeEach(func() {
+			firingResponse = &metrics.AlertResponse{
+				Data: metrics.AlertData{
+					Result: []metrics.AlertResult{
+						{Metric: map[string]string{"alertname": "FlappingAlert"}},
+					},
+				},
+			}
+		})
+		Context("When an alert must be firing for a duration", func() {
+			BeforeEach(func() {
+				config.HealthCheck.AlertFiringDuration = 10
+			})
+			It("will ignore an alert which has not been firing for long enough", func() {
+				gomock.InOrder(
+					mockMetricsClient.EXPECT().Query(gomock.Any()).Return(firingResponse, nil),
+					mockMetricsClient.EXPECT().Query(gomock.Any()).DoAndReturn(
+						func(query string) (*metrics.AlertResponse, error) {
+							Expect(query).To(HavePrefix("ALERTS_FOR_STATE{"))
+							Expect(query).To(HaveSuffix("} < time() - 600"))
+							return &metrics.AlertResponse{}, nil
+						}),
+					mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+				)
+				result, err := performClusterHealthCheck(mockKubeClient, mockMetricsClient, mockCVClient, config, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeTrue())
+			})
+			It("will fail on an alert which has been firing for long enough", func() {
+				gomock.InOrder(
+					mockMetricsClient.EXPECT().Query(gomock.Any()).Return(firingResponse, nil),
+					mockMetricsClient.EXPECT().Query(gomock.Any()).Return(firingResponse, nil),
+				)
+				result, err := performClusterHealthCheck(mockKubeClient, mockMetricsClient, mockCVClient, config, logger)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("FlappingAlert"))
+				Expect(result).To(BeFalse())
+			})
+		})
+		Context("When an alert must have fired a number of times", func() {
+			var now = float64(time.Now().Unix())
+			BeforeEach(func() {
+				config.HealthCheck.AlertFiringOccurrences = 2
+				config.HealthCheck.AlertFiringWindow = 60
+			})
+			It("will ignore an alert which has only just fired once", func() {
+				history := &metrics.AlertResponse{
+					Data: metrics.AlertData{
+						Result: []metrics.AlertResult{
+							{
+								Metric: map[string]string{"alertname": "FlappingAlert"},
+								Values: [][]interface{}{{now - 60, "1"}, {now - 30, "1"}, {now, "1"}},
+							},
+						},
+					},
+				}
+				gomock.InOrder(
+					mockMetricsClient.EXPECT().Query(gomock.Any()).Return(firingResponse, nil),
+					mockMetricsClient.EXPECT().QueryRange(gomock.Any(), gomock.Any(), gomock.Any(), alertHistoryStep).Return(history, nil),
+					mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+				)
+				result, err := performClusterHealthCheck(mockKubeClient, mockMetricsClient, mockCVClient, config, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeTrue())
+			})
+			It("will fail on an alert which has fired continuously across the window", func() {
+				continuous := metrics.AlertResult{Metric: map[string]string{"alertname": "FlappingAlert"}}
+				for t := now - 3600; t <= now; t += alertHistoryStep.Seconds() {
+					continuous.Values = append(continuous.Values, []interface{}{t, "1"})
+				}
+				history := &metrics.AlertResponse{
+					Data: metrics.AlertData{
+						Result: []metrics.AlertResult{continuous},
+					},
+				}
+				gomock.InOrder(
+					mockMetricsClient.EXPECT().Query(gomock.Any()).Return(firingResponse, nil),
+					mockMetricsClient.EXPECT().QueryRange(gomock.Any(), gomock.Any(), gomock.Any(), alertHistoryStep).Return(history, nil),
+				)
+				result, err := performClusterHealthCheck(mockKubeClient, mockMetricsClient, mockCVClient, config, logger)
 				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("FlappingAlert"))
 				Expect(result).To(BeFalse())
 			})
+			It("will fail on an alert which has fired repeatedly", func() {
+				history := &metrics.AlertResponse{
+					Data: metrics.AlertData{
+						Result: []metrics.AlertResult{
+							{
+								Metric: map[string]string{"alertname": "FlappingAlert"},
+								Values: [][]interface{}{{now - 600, "1"}, {now - 30, "1"}, {now, "1"}},
+							},
+						},
+					},
+				}
+				gomock.InOrder(
+					mockMetricsClient.EXPECT().Query(gomock.Any()).Return(firingResponse, nil),
+					mockMetricsClient.EXPECT().QueryRange(gomock.Any(), gomock.Any(), gomock.Any(), alertHistoryStep).Return(history, nil),
+				)
+				result, err := performClusterHealthCheck(mockKubeClient, mockMetricsClient, mockCVClient, config, logger)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("FlappingAlert"))
+				Expect(result).To(BeFalse())
+			})
+			It("will abort if the alert history can't be queried", func() {
+				gomock.InOrder(
+					mockMetricsClient.EXPECT().Query(gomock.Any()).Return(firingResponse, nil),
+					mockMetricsClient.EXPECT().QueryRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("fake error")),
+				)
+				result, err := performClusterHealthCheck(mockKubeClient, mockMetricsClient, mockCVClient, config, logger)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("unable to query critical alert history"))
+				Expect(result).To(BeFalse())
+			})
+		})
+	})
+
+	Context("When nodes are unhealthy", func() {
+		It("will not satisfy a pre-Upgrade health check", func() {
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(&metrics.AlertResponse{}, nil),
+				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+				mockMachineryClient.EXPECT().HasUnhealthyNodes(gomock.Any()).Return(&machinery.HasUnhealthyNodesResult{Unhealthy: []string{"worker-1 (NotReady)"}}, nil),
+				mockMetricsClient.EXPECT().UpdateMetricClusterCheckFailed(upgradeConfig.Name),
+			)
+			result, err := PreClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("worker-1 (NotReady)"))
+			Expect(result).To(BeFalse())
+		})
+	})
+
+	Context("When machine config pools are degraded", func() {
+		It("will not satisfy a pre-Upgrade health check", func() {
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(&metrics.AlertResponse{}, nil),
+				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+				mockMachineryClient.EXPECT().HasUnhealthyNodes(gomock.Any()).Return(&machinery.HasUnhealthyNodesResult{Unhealthy: []string{}}, nil),
+				mockMachineryClient.EXPECT().HasDegradedPools(gomock.Any()).Return(&machinery.HasDegradedPoolsResult{Degraded: []string{"worker"}}, nil),
+				mockMetricsClient.EXPECT().UpdateMetricClusterCheckFailed(upgradeConfig.Name),
+			)
+			result, err := PreClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("degraded machine config pools: worker"))
+			Expect(result).To(BeFalse())
+		})
+	})
+
+	Context("When operators are not upgradeable", func() {
+		JustBeforeEach(func() {
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(&metrics.AlertResponse{}, nil),
+				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+				mockMachineryClient.EXPECT().HasUnhealthyNodes(gomock.Any()).Return(&machinery.HasUnhealthyNodesResult{Unhealthy: []string{}}, nil),
+				mockMachineryClient.EXPECT().HasDegradedPools(gomock.Any()).Return(&machinery.HasDegradedPoolsResult{Degraded: []string{}}, nil),
+				mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
+			)
+		})
+		It("will not satisfy a pre-Upgrade health check for a minor upgrade", func() {
+			upgradeConfig.Spec.Desired.Version = "4.6.1"
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasNonUpgradeableOperators().Return(&clusterversion.HasNonUpgradeableOperatorsResult{NonUpgradeable: []string{"storage (AdminAckRequired)"}}, nil),
+				mockMetricsClient.EXPECT().UpdateMetricClusterCheckFailed(upgradeConfig.Name),
+			)
+			result, err := PreClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("operators blocking minor upgrade: storage (AdminAckRequired)"))
+			Expect(result).To(BeFalse())
+		})
+		It("will satisfy a pre-Upgrade health check for a patch upgrade", func() {
+			mockMetricsClient.EXPECT().UpdateMetricClusterCheckSucceeded(upgradeConfig.Name)
+			result, err := PreClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+	})
+
+	Context("When PodDisruptionBudgets block node drains", func() {
+		var pdbList *policyv1beta1.PodDisruptionBudgetList
+		BeforeEach(func() {
+			upgradeConfig.Status.History = []upgradev1alpha1.UpgradeHistory{{Version: upgradeConfig.Spec.Desired.Version}}
+			zero := intstr.FromInt(0)
+			pdbList = &policyv1beta1.PodDisruptionBudgetList{
+				Items: []policyv1beta1.PodDisruptionBudget{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "blocking", Namespace: "app"},
+						Status:     policyv1beta1.PodDisruptionBudgetStatus{ExpectedPods: 2, DisruptionsAllowed: 0},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "zero-unavailable", Namespace: "openshift-monitoring"},
+						Spec:       policyv1beta1.PodDisruptionBudgetSpec{MaxUnavailable: &zero},
+						Status:     policyv1beta1.PodDisruptionBudgetStatus{ExpectedPods: 2, DisruptionsAllowed: 1},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "allowing", Namespace: "app"},
+						Status:     policyv1beta1.PodDisruptionBudgetStatus{ExpectedPods: 2, DisruptionsAllowed: 1},
+					},
+				},
+			}
+		})
+		It("will not check PodDisruptionBudgets when no action is configured", func() {
+			result, err := performBlockingPDBCheck(mockKubeClient, config, upgradeConfig, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+			Expect(upgradeConfig.Status.History[0].BlockingPodDisruptionBudgets).To(BeEmpty())
+		})
+		It("will record and warn of blocking PodDisruptionBudgets", func() {
+			config.HealthCheck.BlockingPDBs.Action = blockingPDBActionWarn
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *pdbList).Return(nil)
+			result, err := performBlockingPDBCheck(mockKubeClient, config, upgradeConfig, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+			Expect(upgradeConfig.Status.History[0].BlockingPodDisruptionBudgets).To(ConsistOf("app/blocking", "openshift-monitoring/zero-unavailable"))
+		})
+		It("will not record blocking PodDisruptionBudgets in excluded namespaces", func() {
+			config.HealthCheck.BlockingPDBs.Action = blockingPDBActionWarn
+			config.HealthCheck.BlockingPDBs.ExcludedNamespaces = []string{"^openshift-.*"}
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *pdbList).Return(nil)
+			result, err := performBlockingPDBCheck(mockKubeClient, config, upgradeConfig, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+			Expect(upgradeConfig.Status.History[0].BlockingPodDisruptionBudgets).To(ConsistOf("app/blocking"))
+		})
+		It("will not satisfy a pre-Upgrade health check when configured to fail", func() {
+			config.HealthCheck.BlockingPDBs.Action = blockingPDBActionFail
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(&metrics.AlertResponse{}, nil),
+				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+				mockMachineryClient.EXPECT().HasUnhealthyNodes(gomock.Any()).Return(&machinery.HasUnhealthyNodesResult{Unhealthy: []string{}}, nil),
+				mockMachineryClient.EXPECT().HasDegradedPools(gomock.Any()).Return(&machinery.HasDegradedPoolsResult{Degraded: []string{}}, nil),
+				mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *pdbList).Return(nil),
+				mockMetricsClient.EXPECT().UpdateMetricClusterCheckFailed(upgradeConfig.Name),
+			)
+			result, err := PreClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("app/blocking"))
+			Expect(result).To(BeFalse())
+			Expect(upgradeConfig.Status.History[0].BlockingPodDisruptionBudgets).To(ConsistOf("app/blocking", "openshift-monitoring/zero-unavailable"))
 		})
 	})
 
@@ -254,14 +552,14 @@ var _ = Describe("ClusterUpgrader verification and health tests", func() {
 		It("will abort Pre-Upgrade check", func() {
 			mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil)
 			mockMetricsClient.EXPECT().UpdateMetricClusterCheckFailed(upgradeConfig.Name)
-			result, err := PreClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachinery, []ac.AvailabilityChecker{mockAC}, logger)
+			result, err := PreClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("unable to query critical alerts"))
 			Expect(result).To(BeFalse())
 		})
 		It("will abort Post-Upgrade check", func() {
 			mockMetricsClient.EXPECT().UpdateMetricClusterCheckFailed(upgradeConfig.Name)
-			result, err := PostClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachinery, []ac.AvailabilityChecker{mockAC}, logger)
+			result, err := PostClusterHealthCheck(mockKubeClient, config, mockScaler, mockDrainStrategyBuilder, mockMetricsClient, mockMaintClient, mockCVClient, mockEMClient, upgradeConfig, mockMachineryClient, []ac.AvailabilityChecker{mockAC}, logger)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("unable to query critical alerts"))
 			Expect(result).To(BeFalse())
@@ -0,0 +1,143 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// adminGatesNamespace holds the admin-gates ConfigMap declaring acknowledgements required by the CVO
+	adminGatesNamespace = "openshift-config-managed"
+	// adminGatesConfigMap declares the acknowledgements required before upgrading from a given version
+	adminGatesConfigMap = "admin-gates"
+	// adminAcksNamespace holds the admin-acks ConfigMap recording given acknowledgements
+	adminAcksNamespace = "openshift-config"
+	// adminAcksConfigMap records the acknowledgements given by cluster administrators
+	adminAcksConfigMap = "admin-acks"
+	// adminAckedValue is the admin-acks value the CVO treats as an acknowledgement
+	adminAckedValue = "true"
+	// autoAcknowledgedBy identifies acknowledgements given by this operator
+	autoAcknowledgedBy = "managed-upgrade-operator"
+	// adminAcknowledgedBy identifies acknowledgements given by cluster administrators
+	adminAcknowledgedBy = "cluster administrator"
+	// autoAcknowledgedAnnotation records the admin-acks keys acknowledged by this operator
+	autoAcknowledgedAnnotation = "upgrade.managed.openshift.io/acknowledged-by-operator"
+)
+
+// adminGateKeyRegex matches admin-gates keys of the form ack-<major>.<minor>-<description>, as used by the CVO
+var adminGateKeyRegex = regexp.MustCompile(`^ack-([4-5])[.]([0-9]{1,})-[^-]`)
+
+// adminGate is an acknowledgement declared in the admin-gates ConfigMap
+type adminGate struct {
+	Key         string
+	Description string
+	Acked       bool
+	AutoAcked   bool
+}
+
+// acknowledgedBy returns who acknowledged the gate
+func (g adminGate) acknowledgedBy() string {
+	if g.AutoAcked {
+		return autoAcknowledgedBy
+	}
+	return adminAcknowledgedBy
+}
+
+// getAdminGates returns the admin gates which apply to upgrades from the current version, noting
+// whether each has been acknowledged in the admin-acks ConfigMap
+func getAdminGates(c client.Client, currentVersion semver.Version) ([]adminGate, error) {
+	gatesCm := &corev1.ConfigMap{}
+	err := c.Get(context.TODO(), types.NamespacedName{Namespace: adminGatesNamespace, Name: adminGatesConfigMap}, gatesCm)
+	if err != nil {
+		// Admin gates are only declared from OpenShift 4.8
+		if errors.IsNotFound(err) {
+			return []adminGate{}, nil
+		}
+		return nil, err
+	}
+
+	acksCm := &corev1.ConfigMap{}
+	err = c.Get(context.TODO(), types.NamespacedName{Namespace: adminAcksNamespace, Name: adminAcksConfigMap}, acksCm)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	autoAcked := map[string]bool{}
+	for _, key := range getAutoAcknowledgedKeys(acksCm) {
+		autoAcked[key] = true
+	}
+
+	keys := make([]string, 0, len(gatesCm.Data))
+	for key := range gatesCm.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	gates := []adminGate{}
+	for _, key := range keys {
+		matches := adminGateKeyRegex.FindStringSubmatch(key)
+		if len(matches) != 3 {
+			continue
+		}
+		if matches[1] != fmt.Sprint(currentVersion.Major) || matches[2] != fmt.Sprint(currentVersion.Minor) {
+			continue
+		}
+		gates = append(gates, adminGate{
+			Key:         key,
+			Description: gatesCm.Data[key],
+			Acked:       acksCm.Data[key] == adminAckedValue,
+			AutoAcked:   acksCm.Data[key] == adminAckedValue && autoAcked[key],
+		})
+	}
+
+	return gates, nil
+}
+
+// acknowledgeAdminGates sets the given gates as acknowledged in the admin-acks ConfigMap, creating it
+// if it does not exist, and records that the operator acknowledged them in the ConfigMap's annotations
+func acknowledgeAdminGates(c client.Client, gates []adminGate) error {
+	acksCm := &corev1.ConfigMap{}
+	err := c.Get(context.TODO(), types.NamespacedName{Namespace: adminAcksNamespace, Name: adminAcksConfigMap}, acksCm)
+	notFound := errors.IsNotFound(err)
+	if err != nil && !notFound {
+		return err
+	}
+
+	if acksCm.Data == nil {
+		acksCm.Data = map[string]string{}
+	}
+	if acksCm.Annotations == nil {
+		acksCm.Annotations = map[string]string{}
+	}
+	autoAcked := getAutoAcknowledgedKeys(acksCm)
+	for _, gate := range gates {
+		acksCm.Data[gate.Key] = adminAckedValue
+		autoAcked = append(autoAcked, gate.Key)
+	}
+	acksCm.Annotations[autoAcknowledgedAnnotation] = strings.Join(autoAcked, ",")
+
+	if notFound {
+		acksCm.Namespace = adminAcksNamespace
+		acksCm.Name = adminAcksConfigMap
+		return c.Create(context.TODO(), acksCm)
+	}
+	return c.Update(context.TODO(), acksCm)
+}
+
+// getAutoAcknowledgedKeys returns the admin-acks keys recorded as acknowledged by this operator
+func getAutoAcknowledgedKeys(acksCm *corev1.ConfigMap) []string {
+	value := acksCm.Annotations[autoAcknowledgedAnnotation]
+	if len(value) == 0 {
+		return []string{}
+	}
+	return strings.Split(value, ",")
+}
@@ -0,0 +1,97 @@
+package validation
+
+import (
+	"fmt"
+)
+
+const (
+	// GraphSourceUpstream validates the desired version against the ClusterVersion's upstream update graph
+	GraphSourceUpstream = "upstream"
+	// GraphSourceUpdateService validates the desired version against a local OpenShift Update Service
+	GraphSourceUpdateService = "updateService"
+	// GraphSourceConfigMap validates the desired version against update graph data held in a ConfigMap
+	GraphSourceConfigMap = "configMap"
+	// GraphSourceReleaseSignature skips update graph validation and instead requires the desired
+	// release image's signature to be present on the cluster
+	GraphSourceReleaseSignature = "releaseSignature"
+
+	defaultGraphConfigMapKey = "graph.json"
+)
+
+// ValidationConfig holds the configurable behaviour of UpgradeConfig validation
+type ValidationConfig struct {
+	RemovedAPIs RemovedAPIsConfig `yaml:"removedAPIs"`
+	AdminAcks   AdminAcksConfig   `yaml:"adminAcks"`
+	Graph       GraphConfig       `yaml:"graph"`
+}
+
+// RemovedAPIsConfig configures how usage of APIs removed in the desired version is handled
+type RemovedAPIsConfig struct {
+	// Block fails validation while APIs removed in the desired version are in use, rather than only reporting them
+	Block bool `yaml:"block"`
+}
+
+// AdminAcksConfig configures how administrator acknowledgements required by the desired version are handled
+type AdminAcksConfig struct {
+	// AutoAcknowledge gives pending acknowledgements on the administrator's behalf, rather than failing validation
+	AutoAcknowledge bool `yaml:"autoAcknowledge"`
+}
+
+// GraphConfig configures where the update graph used to validate the desired version is read from
+type GraphConfig struct {
+	// Source is one of upstream, updateService, configMap or releaseSignature, defaulting to upstream
+	Source string `yaml:"source"`
+	// URL of the local OpenShift Update Service graph API, used by the updateService source
+	URL string `yaml:"url"`
+	// ConfigMap holding update graph data, used by the configMap source
+	ConfigMap GraphConfigMapConfig `yaml:"configMap"`
+	// ReleaseImages maps versions to their release image pull specs, used by the releaseSignature source
+	ReleaseImages map[string]string `yaml:"releaseImages"`
+}
+
+// GraphConfigMapConfig locates update graph data held in a ConfigMap
+type GraphConfigMapConfig struct {
+	Namespace string `yaml:"namespace"`
+	Name      string `yaml:"name"`
+	// Key holding the graph JSON, defaulting to graph.json
+	Key string `yaml:"key"`
+}
+
+// IsValid returns an error if the validation config is invalid
+func (cfg *ValidationConfig) IsValid() error {
+	return cfg.Graph.IsValid()
+}
+
+// IsValid returns an error if the graph config is invalid for its source
+func (cfg *GraphConfig) IsValid() error {
+	switch cfg.GetSource() {
+	case GraphSourceUpstream, GraphSourceReleaseSignature:
+	case GraphSourceUpdateService:
+		if len(cfg.URL) == 0 {
+			return fmt.Errorf("config validation graph url is required for the %s source", GraphSourceUpdateService)
+		}
+	case GraphSourceConfigMap:
+		if len(cfg.ConfigMap.Namespace) == 0 || len(cfg.ConfigMap.Name) == 0 {
+			return fmt.Errorf("config validation graph configMap is required for the %s source", GraphSourceConfigMap)
+		}
+	default:
+		return fmt.Errorf("config validation graph source %s is invalid", cfg.Source)
+	}
+	return nil
+}
+
+// GetSource returns the configured update graph source, defaulting to upstream
+func (cfg *GraphConfig) GetSource() string {
+	if len(cfg.Source) == 0 {
+		return GraphSourceUpstream
+	}
+	return cfg.Source
+}
+
+// GetConfigMapKey returns the ConfigMap key holding the update graph, defaulting to graph.json
+func (cfg *GraphConfig) GetConfigMapKey() string {
+	if len(cfg.ConfigMap.Key) == 0 {
+		return defaultGraphConfigMapKey
+	}
+	return cfg.ConfigMap.Key
+}
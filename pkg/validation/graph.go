@@ -0,0 +1,199 @@
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/google/uuid"
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/cluster-version-operator/pkg/cincinnati"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
+)
+
+const (
+	// releaseChannelsMetadata lists the channels a release belongs to in update graph node metadata
+	releaseChannelsMetadata = "io.openshift.upgrades.graph.release.channels"
+	// releaseSignatureNamespace holds the ConfigMaps of release image signatures the CVO verifies against
+	releaseSignatureNamespace = "openshift-config-managed"
+	// releaseSignatureLabel marks ConfigMaps holding release image signatures
+	releaseSignatureLabel = "release.openshift.io/verification-signatures"
+)
+
+// graphData is the Cincinnati update graph format
+type graphData struct {
+	Nodes            []graphNode       `json:"nodes"`
+	Edges            [][]int           `json:"edges"`
+	ConditionalEdges []conditionalEdge `json:"conditionalEdges"`
+}
+
+type graphNode struct {
+	Version  string            `json:"version"`
+	Payload  string            `json:"payload"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+// conditionalEdge holds updates which are only recommended when none of their risks apply to the cluster
+type conditionalEdge struct {
+	Edges []conditionalEdgeVersions  `json:"edges"`
+	Risks []cv.ConditionalUpdateRisk `json:"risks"`
+}
+
+type conditionalEdgeVersions struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// getCincinnatiUpdates returns the updates available from the current version in the given channel,
+// as served by a Cincinnati compatible upstream
+func getCincinnatiUpdates(clusterId uuid.UUID, upstreamURI *url.URL, channel string, currentVersion semver.Version) ([]configv1.Update, error) {
+	updates, err := cincinnati.NewClient(clusterId).GetUpdates(upstreamURI.String(), channel, currentVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var cvoUpdates []configv1.Update
+	for _, update := range updates {
+		cvoUpdates = append(cvoUpdates, configv1.Update{
+			Version: update.Version.String(),
+			Image:   update.Image,
+		})
+	}
+	return cvoUpdates, nil
+}
+
+// getConfigMapGraph returns the update graph data held in a ConfigMap
+func getConfigMapGraph(c client.Client, cfg GraphConfig) (*graphData, error) {
+	cm := &corev1.ConfigMap{}
+	err := c.Get(context.TODO(), types.NamespacedName{Namespace: cfg.ConfigMap.Namespace, Name: cfg.ConfigMap.Name}, cm)
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := cm.Data[cfg.GetConfigMapKey()]
+	if !ok {
+		return nil, fmt.Errorf("ConfigMap %s/%s has no %s key", cm.Namespace, cm.Name, cfg.GetConfigMapKey())
+	}
+
+	graph := &graphData{}
+	err = json.Unmarshal([]byte(raw), graph)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse update graph in ConfigMap %s/%s: %v", cm.Namespace, cm.Name, err)
+	}
+	return graph, nil
+}
+
+// getUpdates returns the nodes reachable by an edge from the current version which belong to the
+// given channel. Nodes without channel metadata are assumed to belong to the channel.
+func (g graphData) getUpdates(channel string, currentVersion semver.Version) ([]configv1.Update, error) {
+	currentIdx := -1
+	for i, node := range g.Nodes {
+		version, err := semver.Parse(node.Version)
+		if err == nil && version.EQ(currentVersion) {
+			currentIdx = i
+			break
+		}
+	}
+	if currentIdx < 0 {
+		return nil, fmt.Errorf("currently installed version %s not found in the update graph", currentVersion)
+	}
+
+	var updates []configv1.Update
+	for _, edge := range g.Edges {
+		if len(edge) != 2 {
+			return nil, fmt.Errorf("expected 2 fields in update graph edge, found %d", len(edge))
+		}
+		if edge[0] != currentIdx {
+			continue
+		}
+		if edge[1] < 0 || edge[1] >= len(g.Nodes) {
+			return nil, fmt.Errorf("update graph edge destination %d is out of range", edge[1])
+		}
+		node := g.Nodes[edge[1]]
+		if !node.inChannel(channel) {
+			continue
+		}
+		updates = append(updates, configv1.Update{
+			Version: node.Version,
+			Image:   node.Payload,
+		})
+	}
+	return updates, nil
+}
+
+// getConditionalUpdates returns the conditional updates from the current version to nodes in the given channel
+func (g graphData) getConditionalUpdates(channel string, currentVersion semver.Version) []cv.ConditionalUpdate {
+	updates := []cv.ConditionalUpdate{}
+	for _, conditional := range g.ConditionalEdges {
+		for _, edge := range conditional.Edges {
+			from, err := semver.Parse(edge.From)
+			if err != nil || !from.EQ(currentVersion) {
+				continue
+			}
+			to, err := semver.Parse(edge.To)
+			if err != nil {
+				continue
+			}
+			for _, node := range g.Nodes {
+				version, err := semver.Parse(node.Version)
+				if err != nil || !version.EQ(to) || !node.inChannel(channel) {
+					continue
+				}
+				updates = append(updates, cv.ConditionalUpdate{
+					Release: configv1.Release{Version: node.Version, Image: node.Payload},
+					Risks:   conditional.Risks,
+				})
+			}
+		}
+	}
+	return updates
+}
+
+func (n graphNode) inChannel(channel string) bool {
+	channels, ok := n.Metadata[releaseChannelsMetadata]
+	if !ok {
+		return true
+	}
+	for _, c := range strings.Split(channels, ",") {
+		if strings.TrimSpace(c) == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// hasReleaseSignature returns true if a signature for the digest of the given release image
+// is held in a release signature ConfigMap, as the CVO requires to verify the release
+func hasReleaseSignature(c client.Client, image string) (bool, error) {
+	parts := strings.SplitN(image, "@", 2)
+	if len(parts) != 2 || !strings.HasPrefix(parts[1], "sha256:") {
+		return false, fmt.Errorf("release image %s is not referenced by digest", image)
+	}
+	signaturePrefix := strings.Replace(parts[1], ":", "-", 1) + "-"
+
+	cmList := &corev1.ConfigMapList{}
+	err := c.List(context.TODO(), cmList, client.InNamespace(releaseSignatureNamespace), client.HasLabels{releaseSignatureLabel})
+	if err != nil {
+		return false, err
+	}
+
+	for _, cm := range cmList.Items {
+		for key := range cm.BinaryData {
+			if strings.HasPrefix(key, signaturePrefix) {
+				return true, nil
+			}
+		}
+		for key := range cm.Data {
+			if strings.HasPrefix(key, signaturePrefix) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
@@ -36,6 +36,21 @@ func (m *MockValidator) EXPECT() *MockValidatorMockRecorder {
 	return m.recorder
 }
 
+// AcknowledgeAdminGates mocks base method
+func (m *MockValidator) AcknowledgeAdminGates(arg0 *v1.ClusterVersion, arg1 string, arg2 logr.Logger) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcknowledgeAdminGates", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcknowledgeAdminGates indicates an expected call of AcknowledgeAdminGates
+func (mr *MockValidatorMockRecorder) AcknowledgeAdminGates(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcknowledgeAdminGates", reflect.TypeOf((*MockValidator)(nil).AcknowledgeAdminGates), arg0, arg1, arg2)
+}
+
 // IsValidUpgradeConfig mocks base method
 func (m *MockValidator) IsValidUpgradeConfig(arg0 *v1alpha1.UpgradeConfig, arg1 *v1.ClusterVersion, arg2 logr.Logger) (validation.ValidatorResult, error) {
 	m.ctrl.T.Helper()
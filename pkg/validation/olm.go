@@ -0,0 +1,100 @@
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/blang/semver"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// csvPropertiesAnnotation holds the OLM properties declared by a ClusterServiceVersion
+	csvPropertiesAnnotation = "operatorframework.io/properties"
+	// csvCopiedFromLabel marks the copies of a ClusterServiceVersion which OLM places in watched namespaces
+	csvCopiedFromLabel = "olm.copiedFrom"
+	// maxOpenShiftVersionProperty is the OLM property declaring the newest OpenShift minor an operator supports
+	maxOpenShiftVersionProperty = "olm.maxOpenShiftVersion"
+)
+
+var csvListGVK = schema.GroupVersionKind{
+	Group:   "operators.coreos.com",
+	Version: "v1alpha1",
+	Kind:    "ClusterServiceVersionList",
+}
+
+type olmProperties struct {
+	Properties []olmProperty `json:"properties"`
+}
+
+type olmProperty struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// getIncompatibleOperators returns the installed ClusterServiceVersions which declare an
+// olm.maxOpenShiftVersion lower than the desired version, along with that maximum version
+func getIncompatibleOperators(c client.Client, desiredVersion semver.Version) ([]string, error) {
+	csvList := &unstructured.UnstructuredList{}
+	csvList.SetGroupVersionKind(csvListGVK)
+	err := c.List(context.TODO(), csvList)
+	if err != nil {
+		// Without OLM installed there are no operators to check
+		if meta.IsNoMatchError(err) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	incompatible := []string{}
+	for _, csv := range csvList.Items {
+		if _, ok := csv.GetLabels()[csvCopiedFromLabel]; ok {
+			continue
+		}
+		maxVersion, ok := getMaxOpenShiftVersion(csv.GetAnnotations()[csvPropertiesAnnotation])
+		if !ok {
+			continue
+		}
+		if desiredVersion.Major > maxVersion.Major || (desiredVersion.Major == maxVersion.Major && desiredVersion.Minor > maxVersion.Minor) {
+			incompatible = append(incompatible, fmt.Sprintf("%s/%s (max %d.%d)", csv.GetNamespace(), csv.GetName(), maxVersion.Major, maxVersion.Minor))
+		}
+	}
+
+	return incompatible, nil
+}
+
+// getMaxOpenShiftVersion parses the olm.maxOpenShiftVersion property from a ClusterServiceVersion's
+// properties annotation, returning false if the property is absent or can't be parsed
+func getMaxOpenShiftVersion(annotation string) (semver.Version, bool) {
+	if len(annotation) == 0 {
+		return semver.Version{}, false
+	}
+
+	props := olmProperties{}
+	err := json.Unmarshal([]byte(annotation), &props)
+	if err != nil {
+		return semver.Version{}, false
+	}
+
+	for _, prop := range props.Properties {
+		if prop.Type != maxOpenShiftVersionProperty {
+			continue
+		}
+		// The value may be declared as either a string or a number
+		var value string
+		if err := json.Unmarshal(prop.Value, &value); err != nil {
+			value = string(prop.Value)
+		}
+		version, err := semver.ParseTolerant(value)
+		if err != nil {
+			return semver.Version{}, false
+		}
+		return version, true
+	}
+
+	return semver.Version{}, false
+}
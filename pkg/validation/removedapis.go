@@ -0,0 +1,116 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// openShiftToKubernetesMinorOffset is the difference between an OpenShift 4 minor version and the
+	// minor version of the Kubernetes release it ships, eg. OpenShift 4.9 ships Kubernetes 1.22
+	openShiftToKubernetesMinorOffset = 13
+)
+
+var apiRequestCountListGVK = schema.GroupVersionKind{
+	Group:   "apiserver.openshift.io",
+	Version: "v1",
+	Kind:    "APIRequestCountList",
+}
+
+// apiRequestCountStatus holds the fields of an APIRequestCount's status used to find consumers of removed APIs
+type apiRequestCountStatus struct {
+	RemovedInRelease string                `json:"removedInRelease"`
+	Last24h          []apiRequestCountHour `json:"last24h"`
+	CurrentHour      apiRequestCountHour   `json:"currentHour"`
+}
+
+type apiRequestCountHour struct {
+	ByNode []apiRequestCountNode `json:"byNode"`
+}
+
+type apiRequestCountNode struct {
+	ByUser []apiRequestCountUser `json:"byUser"`
+}
+
+type apiRequestCountUser struct {
+	UserName     string `json:"username"`
+	RequestCount int64  `json:"requestCount"`
+}
+
+// getRemovedAPIUsage returns the APIs removed in the Kubernetes release shipped by the desired version
+// which have been requested in the last 24 hours, along with the users which requested them
+func getRemovedAPIUsage(c client.Client, desiredVersion semver.Version) ([]string, error) {
+	if desiredVersion.Major != 4 {
+		return []string{}, nil
+	}
+	targetKubeMinor := desiredVersion.Minor + openShiftToKubernetesMinorOffset
+
+	requestCountList := &unstructured.UnstructuredList{}
+	requestCountList.SetGroupVersionKind(apiRequestCountListGVK)
+	err := c.List(context.TODO(), requestCountList)
+	if err != nil {
+		// APIRequestCounts are only available from OpenShift 4.8
+		if meta.IsNoMatchError(err) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	usage := []string{}
+	for _, requestCount := range requestCountList.Items {
+		statusObj, ok := requestCount.Object["status"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		status := apiRequestCountStatus{}
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(statusObj, &status)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse APIRequestCount %s: %v", requestCount.GetName(), err)
+		}
+		if len(status.RemovedInRelease) == 0 {
+			continue
+		}
+		removedIn, err := semver.ParseTolerant(status.RemovedInRelease)
+		if err != nil || removedIn.Major != 1 || removedIn.Minor > targetKubeMinor {
+			continue
+		}
+
+		users := getRequestingUsers(status)
+		if len(users) > 0 {
+			usage = append(usage, fmt.Sprintf("%s (removed in %s) used by %s", requestCount.GetName(), status.RemovedInRelease, strings.Join(users, ", ")))
+		}
+	}
+
+	return usage, nil
+}
+
+// getRequestingUsers returns the sorted, de-duplicated users which made requests in the last 24 hours
+func getRequestingUsers(status apiRequestCountStatus) []string {
+	seen := map[string]bool{}
+	hours := append([]apiRequestCountHour{status.CurrentHour}, status.Last24h...)
+	for _, hour := range hours {
+		for _, node := range hour.ByNode {
+			for _, user := range node.ByUser {
+				if user.RequestCount > 0 && len(user.UserName) > 0 {
+					seen[user.UserName] = true
+				}
+			}
+		}
+	}
+
+	users := make([]string, 0, len(seen))
+	for user := range seen {
+		users = append(users, user)
+	}
+	sort.Strings(users)
+	return users
+}
@@ -0,0 +1,107 @@
+package validation
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/blang/semver"
+
+	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+)
+
+const (
+	// riskMatchingRuleAlways declares a risk which applies to every cluster
+	riskMatchingRuleAlways = "Always"
+	// riskMatchingRulePromQL declares a risk which applies to clusters where a PromQL query evaluates to 1
+	riskMatchingRulePromQL = "PromQL"
+)
+
+// getConditionalUpdate returns the conditional update to the desired version, returning false if there is none
+func getConditionalUpdate(updates []cv.ConditionalUpdate, desiredVersion semver.Version) (cv.ConditionalUpdate, bool) {
+	for _, update := range updates {
+		version, err := semver.Parse(update.Release.Version)
+		if err == nil && version.EQ(desiredVersion) {
+			return update, true
+		}
+	}
+	return cv.ConditionalUpdate{}, false
+}
+
+// getApplyingRisks returns the conditional update risks which apply to the cluster. As with the CVO,
+// each risk's matching rules are evaluated in order until one can be evaluated, and a risk which none
+// of its rules can evaluate is assumed to apply.
+func getApplyingRisks(metricsClient metrics.Metrics, risks []cv.ConditionalUpdateRisk) []cv.ConditionalUpdateRisk {
+	applying := []cv.ConditionalUpdateRisk{}
+	for _, risk := range risks {
+		if riskApplies(metricsClient, risk) {
+			applying = append(applying, risk)
+		}
+	}
+	return applying
+}
+
+// getUnacceptedRisks returns the name and URL of each applying risk whose name has not been accepted
+func getUnacceptedRisks(applying []cv.ConditionalUpdateRisk, accepted []string) []string {
+	acceptedNames := map[string]bool{}
+	for _, name := range accepted {
+		acceptedNames[name] = true
+	}
+
+	unaccepted := []string{}
+	for _, risk := range applying {
+		if !acceptedNames[risk.Name] {
+			unaccepted = append(unaccepted, risk.String())
+		}
+	}
+	return unaccepted
+}
+
+func riskApplies(metricsClient metrics.Metrics, risk cv.ConditionalUpdateRisk) bool {
+	for _, rule := range risk.MatchingRules {
+		switch rule.Type {
+		case riskMatchingRuleAlways:
+			return true
+		case riskMatchingRulePromQL:
+			if rule.PromQL == nil || metricsClient == nil {
+				continue
+			}
+			matches, err := evaluatePromQLRule(metricsClient, rule.PromQL.PromQL)
+			if err != nil {
+				continue
+			}
+			return matches
+		}
+	}
+	return true
+}
+
+// evaluatePromQLRule returns true if the query returns a non-zero sample, or an error
+// if the query fails or returns no samples
+func evaluatePromQLRule(metricsClient metrics.Metrics, query string) (bool, error) {
+	response, err := metricsClient.Query(query)
+	if err != nil {
+		return false, err
+	}
+	if len(response.Data.Result) == 0 {
+		return false, fmt.Errorf("query %s returned no samples", query)
+	}
+
+	for _, result := range response.Data.Result {
+		if len(result.Value) != 2 {
+			return false, fmt.Errorf("query %s returned a malformed sample", query)
+		}
+		raw, ok := result.Value[1].(string)
+		if !ok {
+			return false, fmt.Errorf("query %s returned a malformed sample", query)
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return false, fmt.Errorf("query %s returned a malformed sample: %v", query, err)
+		}
+		if value != 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
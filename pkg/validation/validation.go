@@ -4,16 +4,19 @@ package validation
 import (
 	"fmt"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/blang/semver"
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
 	configv1 "github.com/openshift/api/config/v1"
-	"github.com/openshift/cluster-version-operator/pkg/cincinnati"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
 	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	"github.com/openshift/managed-upgrade-operator/pkg/scheduler"
 )
 
 const (
@@ -29,9 +32,14 @@ func NewBuilder() ValidationBuilder {
 //go:generate mockgen -destination=mocks/mockValidation.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/validation Validator
 type Validator interface {
 	IsValidUpgradeConfig(uC *upgradev1alpha1.UpgradeConfig, cV *configv1.ClusterVersion, logger logr.Logger) (ValidatorResult, error)
+	AcknowledgeAdminGates(cV *configv1.ClusterVersion, desiredVersion string, logger logr.Logger) ([]string, error)
 }
 
-type validator struct{}
+type validator struct {
+	client  client.Client
+	config  ValidationConfig
+	metrics metrics.Metrics
+}
 
 // ValidatorResult returns a type that enables validation of upgradeconfigs
 type ValidatorResult struct {
@@ -39,10 +47,66 @@ type ValidatorResult struct {
 	IsValid bool
 	// Indicates that the UpgradeConfig should be actioned to conduct an upgrade
 	IsAvailableUpdate bool
+	// The reason for the validation result
+	Reason ValidationReason
 	// A message associated with the validation result
 	Message string
+	// Installed operators which do not support the desired version
+	IncompatibleOperators []string
+	// APIs removed in the desired version which are still in use, and the users calling them
+	RemovedAPIUsage []string
+	// Administrator acknowledgements given for the upgrade, and who gave them
+	AdminAcks []string
+	// Administrator acknowledgements the upgrade requires which have not been given
+	PendingAdminAcks []string
+	// Risks of a conditional update to the desired version which apply to the cluster
+	ConditionalUpdateRisks []string
+	// Release image of the desired version, when validated against an update graph source the CVO does not use
+	ReleaseImage string
 }
 
+// ValidationReason is a machine readable reason for a validation result
+type ValidationReason string
+
+const (
+	// ReasonValid indicates the UpgradeConfig is valid and the desired version is an available update
+	ReasonValid ValidationReason = "Valid"
+	// ReasonInvalidSchedule indicates the upgradeAt timestamp, upgrade window or recurring schedule could not be parsed
+	ReasonInvalidSchedule ValidationReason = "InvalidSchedule"
+	// ReasonClusterVersionUnavailable indicates the current cluster version could not be determined
+	ReasonClusterVersionUnavailable ValidationReason = "ClusterVersionUnavailable"
+	// ReasonInvalidVersion indicates the desired or current version is not valid semver
+	ReasonInvalidVersion ValidationReason = "InvalidVersion"
+	// ReasonVersionNotComparable indicates the desired and current versions could not be compared
+	ReasonVersionNotComparable ValidationReason = "VersionNotComparable"
+	// ReasonDowngrade indicates the desired version is older than the current version
+	ReasonDowngrade ValidationReason = "Downgrade"
+	// ReasonVersionUnchanged indicates the desired version matches the current version
+	ReasonVersionUnchanged ValidationReason = "VersionUnchanged"
+	// ReasonCheckFailed indicates a validation check could not be completed
+	ReasonCheckFailed ValidationReason = "CheckFailed"
+	// ReasonIncompatibleOperators indicates installed operators do not support the desired version
+	ReasonIncompatibleOperators ValidationReason = "IncompatibleOperators"
+	// ReasonRemovedAPIsInUse indicates APIs removed in the desired version are in use
+	ReasonRemovedAPIsInUse ValidationReason = "RemovedAPIsInUse"
+	// ReasonAdminAckRequired indicates administrator acknowledgements required by the desired version are pending
+	ReasonAdminAckRequired ValidationReason = "AdminAckRequired"
+	// ReasonReleaseImageNotConfigured indicates no release image is configured for the desired version
+	ReasonReleaseImageNotConfigured ValidationReason = "ReleaseImageNotConfigured"
+	// ReasonReleaseImageUnsigned indicates no signature was found for the desired release image
+	ReasonReleaseImageUnsigned ValidationReason = "ReleaseImageUnsigned"
+	// ReasonGraphQueryInvalid indicates the cluster ID or update graph URL could not be parsed to query the update graph
+	ReasonGraphQueryInvalid ValidationReason = "GraphQueryInvalid"
+	// ReasonGraphUnreachable indicates the update graph could not be retrieved
+	ReasonGraphUnreachable ValidationReason = "GraphUnreachable"
+	// ReasonGraphInvalid indicates the update graph could not be interpreted for the current version
+	ReasonGraphInvalid ValidationReason = "GraphInvalid"
+	// ReasonConditionalUpdateRisks indicates unaccepted risks of a conditional update apply to the cluster
+	ReasonConditionalUpdateRisks ValidationReason = "ConditionalUpdateRisks"
+	// ReasonVersionNotInChannel indicates the desired version is not an available update in the desired channel
+	ReasonVersionNotInChannel ValidationReason = "VersionNotInChannel"
+)
+
 // VersionComparison is an in used to compare versions
 type VersionComparison int
 
@@ -58,15 +122,48 @@ const (
 )
 
 func (v *validator) IsValidUpgradeConfig(uC *upgradev1alpha1.UpgradeConfig, cV *configv1.ClusterVersion, logger logr.Logger) (ValidatorResult, error) {
-	// Validate upgradeAt as RFC3339
-	_, err := time.Parse(time.RFC3339, uC.Spec.UpgradeAt)
-	if err != nil {
+	if uC.Spec.Schedule != nil && uC.Spec.Window != nil {
 		return ValidatorResult{
 			IsValid:           false,
 			IsAvailableUpdate: false,
-			Message:           fmt.Sprintf("Failed to parse upgradeAt:%s during validation", uC.Spec.UpgradeAt),
+			Message:           "Only one of schedule and window may be specified",
+			Reason:            ReasonInvalidSchedule,
 		}, nil
 	}
+	if uC.Spec.Schedule != nil {
+		// Validate the recurring schedule can be evaluated
+		err := scheduler.ValidateRecurringSchedule(*uC.Spec.Schedule)
+		if err != nil {
+			return ValidatorResult{
+				IsValid:           false,
+				IsAvailableUpdate: false,
+				Message:           fmt.Sprintf("Failed to evaluate schedule during validation: %v", err),
+				Reason:            ReasonInvalidSchedule,
+			}, nil
+		}
+	} else if uC.Spec.Window != nil {
+		// Validate the upgrade window can be evaluated
+		err := scheduler.ValidateUpgradeWindow(*uC.Spec.Window)
+		if err != nil {
+			return ValidatorResult{
+				IsValid:           false,
+				IsAvailableUpdate: false,
+				Message:           fmt.Sprintf("Failed to evaluate window during validation: %v", err),
+				Reason:            ReasonInvalidSchedule,
+			}, nil
+		}
+	} else {
+		// Validate upgradeAt as RFC3339
+		_, err := time.Parse(time.RFC3339, uC.Spec.UpgradeAt)
+		if err != nil {
+			return ValidatorResult{
+				IsValid:           false,
+				IsAvailableUpdate: false,
+				Message:           fmt.Sprintf("Failed to parse upgradeAt:%s during validation", uC.Spec.UpgradeAt),
+				Reason:            ReasonInvalidSchedule,
+			}, nil
+		}
+	}
 
 	// Validate desired version.
 	dv := uC.Spec.Desired.Version
@@ -76,6 +173,7 @@ func (v *validator) IsValidUpgradeConfig(uC *upgradev1alpha1.UpgradeConfig, cV *
 			IsValid:           false,
 			IsAvailableUpdate: false,
 			Message:           "Failed to get current cluster version during validation",
+			Reason:            ReasonClusterVersionUnavailable,
 		}, err
 	}
 
@@ -86,6 +184,7 @@ func (v *validator) IsValidUpgradeConfig(uC *upgradev1alpha1.UpgradeConfig, cV *
 			IsValid:           false,
 			IsAvailableUpdate: false,
 			Message:           fmt.Sprintf("Failed to parse desired version %s as semver", dv),
+			Reason:            ReasonInvalidVersion,
 		}, nil
 	}
 	currentVersion, err := semver.Parse(version)
@@ -93,7 +192,8 @@ func (v *validator) IsValidUpgradeConfig(uC *upgradev1alpha1.UpgradeConfig, cV *
 		return ValidatorResult{
 			IsValid:           false,
 			IsAvailableUpdate: false,
-			Message:           fmt.Sprintf("Failed to parse desired version %s as semver", version),
+			Message:           fmt.Sprintf("Failed to parse current version %s as semver", version),
+			Reason:            ReasonInvalidVersion,
 		}, nil
 	}
 
@@ -104,6 +204,7 @@ func (v *validator) IsValidUpgradeConfig(uC *upgradev1alpha1.UpgradeConfig, cV *
 			IsValid:           true,
 			IsAvailableUpdate: false,
 			Message:           err.Error(),
+			Reason:            ReasonVersionNotComparable,
 		}, nil
 	}
 
@@ -113,64 +214,246 @@ func (v *validator) IsValidUpgradeConfig(uC *upgradev1alpha1.UpgradeConfig, cV *
 			IsValid:           false,
 			IsAvailableUpdate: false,
 			Message:           fmt.Sprintf("Desired version %s and current version %s could not be compared.", desiredVersion, currentVersion),
+			Reason:            ReasonVersionNotComparable,
 		}, nil
 	case VersionDowngrade:
 		return ValidatorResult{
 			IsValid:           true,
 			IsAvailableUpdate: false,
 			Message:           fmt.Sprintf("Downgrades to desired version %s from %s are unsupported", desiredVersion, currentVersion),
+			Reason:            ReasonDowngrade,
 		}, nil
 	case VersionEqual:
 		return ValidatorResult{
 			IsValid:           true,
 			IsAvailableUpdate: false,
 			Message:           fmt.Sprintf("Desired version %s matches the current version %s", desiredVersion, currentVersion),
+			Reason:            ReasonVersionUnchanged,
 		}, nil
 	case VersionUpgrade:
 		logger.Info(fmt.Sprintf("Desired version %s validated as greater then current version %s", desiredVersion, currentVersion))
 	}
 
-	// Validate available version is in Cincinnati.
-	desiredChannel := uC.Spec.Desired.Channel
-	clusterId, err := uuid.Parse(string(cV.Spec.ClusterID))
+	// Validate installed operators and API consumers support the desired minor version,
+	// and that any administrator acknowledgements it requires have been given.
+	var removedAPIUsage []string
+	var adminAcks []string
+	isMinorUpgrade, err := cv.IsMinorUpgrade(cV, dv)
 	if err != nil {
 		return ValidatorResult{
 			IsValid:           false,
 			IsAvailableUpdate: false,
-			Message:           "",
-		}, nil
-	}
-	upstreamURI, err := url.Parse(getUpstreamURL(cV))
-	if err != nil {
-		return ValidatorResult{
-			IsValid:           false,
-			IsAvailableUpdate: false,
-			Message:           "",
+			Message:           err.Error(),
+			Reason:            ReasonVersionNotComparable,
 		}, nil
 	}
+	if isMinorUpgrade {
+		incompatibleOperators, err := getIncompatibleOperators(v.client, desiredVersion)
+		if err != nil {
+			return ValidatorResult{
+				IsValid:           false,
+				IsAvailableUpdate: false,
+				Message:           "Failed to check installed operators for compatibility",
+				Reason:            ReasonCheckFailed,
+			}, err
+		}
+		if len(incompatibleOperators) > 0 {
+			logger.Info(fmt.Sprintf("Installed operators do not support version %s: %s", desiredVersion, strings.Join(incompatibleOperators, ", ")))
+			return ValidatorResult{
+				IsValid:               false,
+				IsAvailableUpdate:     false,
+				Message:               fmt.Sprintf("installed operators do not support version %s: %s", desiredVersion, strings.Join(incompatibleOperators, ", ")),
+				Reason:                ReasonIncompatibleOperators,
+				IncompatibleOperators: incompatibleOperators,
+			}, nil
+		}
 
-	updates, err := cincinnati.NewClient(clusterId).GetUpdates(upstreamURI.String(), desiredChannel, currentVersion)
-	if err != nil {
-		return ValidatorResult{
-			IsValid:           false,
-			IsAvailableUpdate: false,
-			Message:           "",
-		}, err
+		removedAPIUsage, err = getRemovedAPIUsage(v.client, desiredVersion)
+		if err != nil {
+			return ValidatorResult{
+				IsValid:           false,
+				IsAvailableUpdate: false,
+				Message:           "Failed to check usage of APIs removed in the desired version",
+				Reason:            ReasonCheckFailed,
+			}, err
+		}
+		if len(removedAPIUsage) > 0 {
+			logger.Info(fmt.Sprintf("APIs removed in version %s are in use: %s", desiredVersion, strings.Join(removedAPIUsage, "; ")))
+			if v.config.RemovedAPIs.Block {
+				return ValidatorResult{
+					IsValid:           false,
+					IsAvailableUpdate: false,
+					Message:           fmt.Sprintf("APIs removed in version %s are in use: %s", desiredVersion, strings.Join(removedAPIUsage, "; ")),
+					Reason:            ReasonRemovedAPIsInUse,
+					RemovedAPIUsage:   removedAPIUsage,
+				}, nil
+			}
+		}
+
+		var pendingAdminAcks []string
+		adminAcks, pendingAdminAcks, err = v.checkAdminAcks(currentVersion, logger)
+		if err != nil {
+			return ValidatorResult{
+				IsValid:           false,
+				IsAvailableUpdate: false,
+				Message:           "Failed to check administrator acknowledgements required by the desired version",
+				Reason:            ReasonCheckFailed,
+			}, err
+		}
+		if len(pendingAdminAcks) > 0 {
+			logger.Info(fmt.Sprintf("Upgrade to version %s requires administrator acknowledgement: %s", desiredVersion, strings.Join(pendingAdminAcks, "; ")))
+			return ValidatorResult{
+				IsValid:           false,
+				IsAvailableUpdate: false,
+				Message:           fmt.Sprintf("upgrade to version %s requires administrator acknowledgement: %s", desiredVersion, strings.Join(pendingAdminAcks, "; ")),
+				Reason:            ReasonAdminAckRequired,
+				RemovedAPIUsage:   removedAPIUsage,
+				AdminAcks:         adminAcks,
+				PendingAdminAcks:  pendingAdminAcks,
+			}, nil
+		}
 	}
 
+	// Validate the desired version is available from the configured update graph source.
+	desiredChannel := uC.Spec.Desired.Channel
 	var cvoUpdates []configv1.Update
-	for _, update := range updates {
-		cvoUpdates = append(cvoUpdates, configv1.Update{
-			Version: update.Version.String(),
-			Image:   update.Image,
-		})
+	var conditionalUpdates []cv.ConditionalUpdate
+	switch v.config.Graph.GetSource() {
+	case GraphSourceReleaseSignature:
+		image, ok := v.config.Graph.ReleaseImages[dv]
+		if !ok {
+			return ValidatorResult{
+				IsValid:           false,
+				IsAvailableUpdate: false,
+				Message:           fmt.Sprintf("no release image is configured for version %s", desiredVersion),
+				Reason:            ReasonReleaseImageNotConfigured,
+			}, nil
+		}
+		signed, err := hasReleaseSignature(v.client, image)
+		if err != nil {
+			return ValidatorResult{
+				IsValid:           false,
+				IsAvailableUpdate: false,
+				Message:           fmt.Sprintf("Failed to check the signature of release image %s", image),
+				Reason:            ReasonCheckFailed,
+			}, err
+		}
+		if !signed {
+			logger.Info(fmt.Sprintf("Failed to find a signature for release image %s of version %s", image, desiredVersion))
+			return ValidatorResult{
+				IsValid:           false,
+				IsAvailableUpdate: false,
+				Message:           fmt.Sprintf("cannot find a signature for release image %s of version %s", image, desiredVersion),
+				Reason:            ReasonReleaseImageUnsigned,
+			}, nil
+		}
+		return ValidatorResult{
+			IsValid:           true,
+			IsAvailableUpdate: true,
+			Message:           "UpgradeConfig is valid",
+			Reason:            ReasonValid,
+			RemovedAPIUsage:   removedAPIUsage,
+			AdminAcks:         adminAcks,
+			ReleaseImage:      image,
+		}, nil
+	case GraphSourceConfigMap:
+		graph, err := getConfigMapGraph(v.client, v.config.Graph)
+		if err != nil {
+			return ValidatorResult{
+				IsValid:           false,
+				IsAvailableUpdate: false,
+				Message:           "Failed to read the update graph ConfigMap",
+				Reason:            ReasonGraphUnreachable,
+			}, err
+		}
+		cvoUpdates, err = graph.getUpdates(desiredChannel, currentVersion)
+		if err != nil {
+			return ValidatorResult{
+				IsValid:           false,
+				IsAvailableUpdate: false,
+				Message:           "Failed to find available updates in the update graph",
+				Reason:            ReasonGraphInvalid,
+			}, err
+		}
+		conditionalUpdates = graph.getConditionalUpdates(desiredChannel, currentVersion)
+	default:
+		upstream := getUpstreamURL(cV)
+		if v.config.Graph.GetSource() == GraphSourceUpdateService {
+			upstream = v.config.Graph.URL
+		}
+		clusterId, err := uuid.Parse(string(cV.Spec.ClusterID))
+		if err != nil {
+			return ValidatorResult{
+				IsValid:           false,
+				IsAvailableUpdate: false,
+				Message:           fmt.Sprintf("Failed to parse cluster ID %s", cV.Spec.ClusterID),
+				Reason:            ReasonGraphQueryInvalid,
+			}, nil
+		}
+		upstreamURI, err := url.Parse(upstream)
+		if err != nil {
+			return ValidatorResult{
+				IsValid:           false,
+				IsAvailableUpdate: false,
+				Message:           fmt.Sprintf("Failed to parse update graph URL %s", upstream),
+				Reason:            ReasonGraphQueryInvalid,
+			}, nil
+		}
+		cvoUpdates, err = getCincinnatiUpdates(clusterId, upstreamURI, desiredChannel, currentVersion)
+		if err != nil {
+			return ValidatorResult{
+				IsValid:           false,
+				IsAvailableUpdate: false,
+				Message:           fmt.Sprintf("Failed to retrieve available updates from %s", upstream),
+				Reason:            ReasonGraphUnreachable,
+			}, err
+		}
+		// Cincinnati clients don't expose conditional edges, so rely on those the CVO has evaluated
+		conditionalUpdates, err = cv.GetConditionalUpdates(v.client)
+		if err != nil {
+			return ValidatorResult{
+				IsValid:           false,
+				IsAvailableUpdate: false,
+				Message:           "Failed to retrieve conditional updates from the ClusterVersion",
+				Reason:            ReasonCheckFailed,
+			}, err
+		}
 	}
 
 	// Check whether the desired version exists in availableUpdates
 	found := false
+	var desiredImage string
 	for _, v := range cvoUpdates {
 		if v.Version == dv && !v.Force {
 			found = true
+			desiredImage = v.Image
+		}
+	}
+
+	// Otherwise check whether the desired version is a conditional update, and if so
+	// whether any of its risks apply which haven't been accepted
+	var conditionalUpdateRisks []string
+	if !found {
+		if update, ok := getConditionalUpdate(conditionalUpdates, desiredVersion); ok {
+			found = true
+			desiredImage = update.Release.Image
+			applying := getApplyingRisks(v.metrics, update.Risks)
+			for _, risk := range applying {
+				conditionalUpdateRisks = append(conditionalUpdateRisks, risk.String())
+			}
+			unaccepted := getUnacceptedRisks(applying, uC.Spec.Desired.AcceptedRisks)
+			if len(unaccepted) > 0 {
+				logger.Info(fmt.Sprintf("Conditional update to version %s has unaccepted risks: %s", desiredVersion, strings.Join(unaccepted, ", ")))
+				return ValidatorResult{
+					IsValid:                false,
+					IsAvailableUpdate:      false,
+					Message:                fmt.Sprintf("conditional update to version %s has risks which apply to the cluster and have not been accepted: %s", desiredVersion, strings.Join(unaccepted, ", ")),
+					Reason:                 ReasonConditionalUpdateRisks,
+					RemovedAPIUsage:        removedAPIUsage,
+					AdminAcks:              adminAcks,
+					ConditionalUpdateRisks: conditionalUpdateRisks,
+				}, nil
+			}
 		}
 	}
 
@@ -180,15 +463,113 @@ func (v *validator) IsValidUpgradeConfig(uC *upgradev1alpha1.UpgradeConfig, cV *
 			IsValid:           false,
 			IsAvailableUpdate: false,
 			Message:           fmt.Sprintf("cannot find version %s in available updates", desiredVersion),
+			Reason:            ReasonVersionNotInChannel,
 		}, nil
 	}
+	// The CVO only offers updates from its own upstream, so record the release image of updates found elsewhere
+	var releaseImage string
+	if v.isOutsideCVOUpstream(cV) {
+		releaseImage = desiredImage
+	}
 	return ValidatorResult{
-		IsValid:           true,
-		IsAvailableUpdate: true,
-		Message:           "UpgradeConfig is valid",
+		IsValid:                true,
+		IsAvailableUpdate:      true,
+		Message:                "UpgradeConfig is valid",
+		Reason:                 ReasonValid,
+		RemovedAPIUsage:        removedAPIUsage,
+		AdminAcks:              adminAcks,
+		ConditionalUpdateRisks: conditionalUpdateRisks,
+		ReleaseImage:           releaseImage,
 	}, nil
 }
 
+// isOutsideCVOUpstream returns true if the configured update graph source is not the upstream the cluster version
+// operator offers updates from
+func (v *validator) isOutsideCVOUpstream(cV *configv1.ClusterVersion) bool {
+	switch v.config.Graph.GetSource() {
+	case GraphSourceConfigMap:
+		return true
+	case GraphSourceUpdateService:
+		return v.config.Graph.URL != getUpstreamURL(cV)
+	}
+	return false
+}
+
+// checkAdminAcks returns the administrator acknowledgements required to upgrade from the current version
+// which have been given, and who gave them, along with those still pending. If configured to give pending
+// acknowledgements on the administrator's behalf, they are not reported as pending, as they will be given
+// once the upgrade starts.
+func (v *validator) checkAdminAcks(currentVersion semver.Version, logger logr.Logger) ([]string, []string, error) {
+	gates, err := getAdminGates(v.client, currentVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	acked := []string{}
+	pending := []string{}
+	for _, gate := range gates {
+		if gate.Acked {
+			acked = append(acked, fmt.Sprintf("%s acknowledged by %s", gate.Key, gate.acknowledgedBy()))
+		} else if v.config.AdminAcks.AutoAcknowledge {
+			logger.Info(fmt.Sprintf("Admin gate %s will be acknowledged when the upgrade starts: %s", gate.Key, gate.Description))
+		} else {
+			pending = append(pending, fmt.Sprintf("%s: %s", gate.Key, gate.Description))
+		}
+	}
+	return acked, pending, nil
+}
+
+// AcknowledgeAdminGates gives any administrator acknowledgements required to upgrade from the cluster's current
+// version which are pending, if configured to, and returns the acknowledgements which have been given and who gave them.
+// Acknowledgements are only required for minor upgrades, so none are given for any other upgrade.
+func (v *validator) AcknowledgeAdminGates(cV *configv1.ClusterVersion, desiredVersion string, logger logr.Logger) ([]string, error) {
+	if !v.config.AdminAcks.AutoAcknowledge {
+		return nil, nil
+	}
+	isMinorUpgrade, err := cv.IsMinorUpgrade(cV, desiredVersion)
+	if err != nil {
+		return nil, err
+	}
+	if !isMinorUpgrade {
+		return nil, nil
+	}
+	version, err := cv.GetCurrentVersion(cV)
+	if err != nil {
+		return nil, err
+	}
+	currentVersion, err := semver.Parse(version)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the current version: %v", err)
+	}
+	gates, err := getAdminGates(v.client, currentVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	acked := []string{}
+	pending := []adminGate{}
+	for _, gate := range gates {
+		if gate.Acked {
+			acked = append(acked, fmt.Sprintf("%s acknowledged by %s", gate.Key, gate.acknowledgedBy()))
+		} else {
+			pending = append(pending, gate)
+		}
+	}
+	if len(pending) == 0 {
+		return acked, nil
+	}
+
+	err = acknowledgeAdminGates(v.client, pending)
+	if err != nil {
+		return nil, fmt.Errorf("unable to acknowledge admin gates: %v", err)
+	}
+	for _, gate := range pending {
+		logger.Info(fmt.Sprintf("Acknowledged admin gate %s: %s", gate.Key, gate.Description))
+		acked = append(acked, fmt.Sprintf("%s acknowledged by %s", gate.Key, autoAcknowledgedBy))
+	}
+	return acked, nil
+}
+
 // compareVersions accepts desiredVersion and currentVersion strings as versions, converts
 // them to semver and then compares them. Returns an indication of whether the desired
 // version constitutes a downgrade, no-op or upgrade, or an error if no valid comparison can occur
@@ -223,7 +604,7 @@ func getUpstreamURL(cV *configv1.ClusterVersion) string {
 // ValidationBuilder is a interface that enables ValidationBuiler implementations
 //go:generate mockgen -destination=mocks/mockValidationBuilder.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/validation ValidationBuilder
 type ValidationBuilder interface {
-	NewClient() (Validator, error)
+	NewClient(c client.Client, cfg ValidationConfig, metricsClient metrics.Metrics) (Validator, error)
 }
 
 // validationBuilder is an empty struct that enables instantiation of this type and its
@@ -231,6 +612,6 @@ type ValidationBuilder interface {
 type validationBuilder struct{}
 
 // NewClient returns a Validator interface or an error if one occurs.
-func (vb *validationBuilder) NewClient() (Validator, error) {
-	return &validator{}, nil
+func (vb *validationBuilder) NewClient(c client.Client, cfg ValidationConfig, metricsClient metrics.Metrics) (Validator, error) {
+	return &validator{client: c, config: cfg, metrics: metricsClient}, nil
 }
@@ -1,19 +1,35 @@
 package validation
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/blang/semver"
 	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jarcoal/httpmock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	configv1 "github.com/openshift/api/config/v1"
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
 	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
 
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -29,10 +45,14 @@ var _ = Describe("Validation of UpgradeConfig CR", func() {
 		testUpgradeConfigName types.NamespacedName
 		testClusterVersion    *configv1.ClusterVersion
 		testLogger            logr.Logger
+		mockCtrl              *gomock.Controller
+		mockKubeClient        *mocks.MockClient
 	)
 
 	BeforeEach(func() {
-		testValidator = &validator{}
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		testValidator = &validator{client: mockKubeClient}
 		testUpgradeConfigName = types.NamespacedName{
 			Name:      "test-upgradeconfig",
 			Namespace: "test-namespace",
@@ -83,6 +103,10 @@ var _ = Describe("Validation of UpgradeConfig CR", func() {
 		}
 	})
 
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
 	Context("Validating UpgradeAt timestamp", func() {
 		Context("When the UpgradeAt timestamp is NOT RFC3339 format", func() {
 			It("Validation is false and error is returned as NOT nil", func() {
@@ -92,6 +116,45 @@ var _ = Describe("Validation of UpgradeConfig CR", func() {
 				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
 				Expect(err).Should(BeNil())
 				Expect(result.IsValid).Should(BeFalse())
+				Expect(result.Reason).Should(Equal(ReasonInvalidSchedule))
+			})
+		})
+		Context("When an upgrade window is specified", func() {
+			It("Validation is false when the window cannot be evaluated", func() {
+				testUpgradeConfig.Spec.UpgradeAt = ""
+				testUpgradeConfig.Spec.Window = &upgradev1alpha1.UpgradeWindow{Start: "2021-06-01T02:00:00Z", End: "2021-06-01T00:00:00Z"}
+
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeFalse())
+				Expect(result.Reason).Should(Equal(ReasonInvalidSchedule))
+			})
+			It("Validation is false when a recurring schedule is also specified", func() {
+				testUpgradeConfig.Spec.Window = &upgradev1alpha1.UpgradeWindow{Start: "2021-06-01T00:00:00Z", End: "2021-06-01T02:00:00Z"}
+				testUpgradeConfig.Spec.Schedule = &upgradev1alpha1.RecurringSchedule{Cron: "0 2 * * 2"}
+
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeFalse())
+				Expect(result.Reason).Should(Equal(ReasonInvalidSchedule))
+			})
+		})
+		Context("When a recurring schedule is specified", func() {
+			It("Validation is false when the schedule cannot be evaluated", func() {
+				testUpgradeConfig.Spec.UpgradeAt = ""
+				testUpgradeConfig.Spec.Schedule = &upgradev1alpha1.RecurringSchedule{Cron: "every tuesday", TimeZone: "Europe/Berlin"}
+
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeFalse())
+				Expect(result.Reason).Should(Equal(ReasonInvalidSchedule))
+			})
+			It("Does not require the UpgradeAt timestamp", func() {
+				testUpgradeConfig.Spec.UpgradeAt = ""
+				testUpgradeConfig.Spec.Schedule = &upgradev1alpha1.RecurringSchedule{Cron: "0 2 * * 2", TimeZone: "Europe/Berlin"}
+
+				result, _ := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(result.Reason).ShouldNot(Equal(ReasonInvalidSchedule))
 			})
 		})
 	})
@@ -115,6 +178,7 @@ var _ = Describe("Validation of UpgradeConfig CR", func() {
 				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
 				Expect(err).Should(BeNil())
 				Expect(result.IsValid).Should(BeFalse())
+				Expect(result.Reason).Should(Equal(ReasonInvalidVersion))
 			})
 		})
 		Context("When the ClusterVersion version is NOT valid", func() {
@@ -160,6 +224,530 @@ var _ = Describe("Validation of UpgradeConfig CR", func() {
 			})
 		})
 	})
+	Context("Validating installed operator compatibility", func() {
+		var csvList *unstructured.UnstructuredList
+
+		newCSV := func(namespace, name, properties string, labels map[string]string) unstructured.Unstructured {
+			csv := unstructured.Unstructured{}
+			csv.SetNamespace(namespace)
+			csv.SetName(name)
+			csv.SetLabels(labels)
+			if len(properties) > 0 {
+				csv.SetAnnotations(map[string]string{csvPropertiesAnnotation: properties})
+			}
+			return csv
+		}
+
+		BeforeEach(func() {
+			testUpgradeConfig.Spec.Desired.Version = "4.9.1"
+			testClusterVersion.Status.History[1].Version = "4.8.10"
+			csvList = &unstructured.UnstructuredList{
+				Items: []unstructured.Unstructured{
+					newCSV("openshift-operators", "compatible.v1.0.0", `{"properties":[{"type":"olm.maxOpenShiftVersion","value":"4.9"}]}`, nil),
+					newCSV("openshift-operators", "unbounded.v1.0.0", "", nil),
+					newCSV("openshift-operators", "incompatible.v1.0.0", `{"properties":[{"type":"olm.maxOpenShiftVersion","value":4.8}]}`, nil),
+					newCSV("my-namespace", "incompatible.v1.0.0", `{"properties":[{"type":"olm.maxOpenShiftVersion","value":4.8}]}`, map[string]string{csvCopiedFromLabel: "openshift-operators"}),
+				},
+			}
+		})
+
+		Context("When an installed operator does not support the desired minor version", func() {
+			It("Validation is false and the operator is named", func() {
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *csvList)
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeFalse())
+				Expect(result.Reason).Should(Equal(ReasonIncompatibleOperators))
+				Expect(result.IncompatibleOperators).Should(Equal([]string{"openshift-operators/incompatible.v1.0.0 (max 4.8)"}))
+			})
+		})
+		Context("When installed operators can't be listed", func() {
+			It("Validation is false and an error is returned", func() {
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error"))
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).ShouldNot(BeNil())
+				Expect(result.IsValid).Should(BeFalse())
+			})
+		})
+		Context("When installed operators support the desired minor version", func() {
+			It("No operators are reported as incompatible", func() {
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *csvList)
+				desiredVersion, _ := semver.Parse("4.8.11")
+				result, err := getIncompatibleOperators(mockKubeClient, desiredVersion)
+				Expect(err).Should(BeNil())
+				Expect(result).Should(BeEmpty())
+			})
+		})
+		Context("When the cluster does not serve ClusterServiceVersions", func() {
+			It("No operators are reported as incompatible", func() {
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(&meta.NoKindMatchError{GroupKind: csvListGVK.GroupKind()})
+				desiredVersion, _ := semver.Parse("4.9.1")
+				result, err := getIncompatibleOperators(mockKubeClient, desiredVersion)
+				Expect(err).Should(BeNil())
+				Expect(result).Should(BeEmpty())
+			})
+		})
+	})
+	Context("Validating usage of removed APIs", func() {
+		var requestCountList *unstructured.UnstructuredList
+
+		newAPIRequestCount := func(name, removedInRelease string, users ...string) unstructured.Unstructured {
+			byUser := []interface{}{}
+			for _, user := range users {
+				byUser = append(byUser, map[string]interface{}{"username": user, "requestCount": int64(3)})
+			}
+			requestCount := unstructured.Unstructured{Object: map[string]interface{}{
+				"status": map[string]interface{}{
+					"removedInRelease": removedInRelease,
+					"last24h": []interface{}{
+						map[string]interface{}{
+							"byNode": []interface{}{
+								map[string]interface{}{"byUser": byUser},
+							},
+						},
+					},
+				},
+			}}
+			requestCount.SetName(name)
+			return requestCount
+		}
+
+		BeforeEach(func() {
+			testUpgradeConfig.Spec.Desired.Version = "4.9.1"
+			testClusterVersion.Status.History[1].Version = "4.8.10"
+			requestCountList = &unstructured.UnstructuredList{
+				Items: []unstructured.Unstructured{
+					newAPIRequestCount("flowschemas.v1beta1.flowcontrol.apiserver.k8s.io", "1.26", "system:serviceaccount:my-app:default"),
+					newAPIRequestCount("ingresses.v1beta1.extensions", "1.22", "system:serviceaccount:my-app:default", "system:admin"),
+					newAPIRequestCount("customresourcedefinitions.v1beta1.apiextensions.k8s.io", "1.22"),
+					newAPIRequestCount("pods.v1", ""),
+				},
+			}
+		})
+
+		Context("When APIs removed in the desired version are in use", func() {
+			It("Reports the APIs and the users calling them", func() {
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *requestCountList)
+				desiredVersion, _ := semver.Parse("4.9.1")
+				result, err := getRemovedAPIUsage(mockKubeClient, desiredVersion)
+				Expect(err).Should(BeNil())
+				Expect(result).Should(Equal([]string{"ingresses.v1beta1.extensions (removed in 1.22) used by system:admin, system:serviceaccount:my-app:default"}))
+			})
+			It("Validation is false when configured to block", func() {
+				testValidator = &validator{client: mockKubeClient, config: ValidationConfig{RemovedAPIs: RemovedAPIsConfig{Block: true}}}
+				gomock.InOrder(
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, unstructured.UnstructuredList{}),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *requestCountList),
+				)
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeFalse())
+				Expect(result.RemovedAPIUsage).Should(HaveLen(1))
+			})
+		})
+		Context("When no APIs removed in the desired version are in use", func() {
+			It("Reports no usage", func() {
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *requestCountList)
+				desiredVersion, _ := semver.Parse("4.8.11")
+				result, err := getRemovedAPIUsage(mockKubeClient, desiredVersion)
+				Expect(err).Should(BeNil())
+				Expect(result).Should(BeEmpty())
+			})
+		})
+		Context("When the cluster does not serve APIRequestCounts", func() {
+			It("Reports no usage", func() {
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(&meta.NoKindMatchError{GroupKind: apiRequestCountListGVK.GroupKind()})
+				desiredVersion, _ := semver.Parse("4.9.1")
+				result, err := getRemovedAPIUsage(mockKubeClient, desiredVersion)
+				Expect(err).Should(BeNil())
+				Expect(result).Should(BeEmpty())
+			})
+		})
+	})
+	Context("Validating administrator acknowledgements", func() {
+		var gatesCm, acksCm *corev1.ConfigMap
+
+		BeforeEach(func() {
+			testUpgradeConfig.Spec.Desired.Version = "4.9.1"
+			testClusterVersion.Status.History[1].Version = "4.8.10"
+			gatesCm = &corev1.ConfigMap{
+				Data: map[string]string{
+					"ack-4.8-kube-1.22-api-removals-in-4.9": "Kubernetes 1.22 removes several APIs",
+					"ack-4.7-old-gate":                      "Applies to upgrades from 4.7",
+					"not-a-gate":                            "Ignored",
+				},
+			}
+			acksCm = &corev1.ConfigMap{Data: map[string]string{}}
+		})
+
+		Context("When a gate for the current version has been acknowledged", func() {
+			It("Records the acknowledgement", func() {
+				acksCm.Data["ack-4.8-kube-1.22-api-removals-in-4.9"] = "true"
+				gomock.InOrder(
+					mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Namespace: adminGatesNamespace, Name: adminGatesConfigMap}, gomock.Any()).SetArg(2, *gatesCm),
+					mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Namespace: adminAcksNamespace, Name: adminAcksConfigMap}, gomock.Any()).SetArg(2, *acksCm),
+				)
+				currentVersion, _ := semver.Parse("4.8.10")
+				acked, pending, err := testValidator.(*validator).checkAdminAcks(currentVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(acked).Should(Equal([]string{"ack-4.8-kube-1.22-api-removals-in-4.9 acknowledged by cluster administrator"}))
+				Expect(pending).Should(BeEmpty())
+			})
+		})
+		Context("When a gate for the current version has not been acknowledged", func() {
+			It("Validation is false and the gate is reported as pending", func() {
+				gomock.InOrder(
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, unstructured.UnstructuredList{}),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, unstructured.UnstructuredList{}),
+					mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, *gatesCm),
+					mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, *acksCm),
+				)
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeFalse())
+				Expect(result.PendingAdminAcks).Should(Equal([]string{"ack-4.8-kube-1.22-api-removals-in-4.9: Kubernetes 1.22 removes several APIs"}))
+			})
+			It("Does not report the gate as pending when configured to acknowledge it", func() {
+				testValidator = &validator{client: mockKubeClient, config: ValidationConfig{AdminAcks: AdminAcksConfig{AutoAcknowledge: true}}}
+				gomock.InOrder(
+					mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, *gatesCm),
+					mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, *acksCm),
+				)
+				mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+				currentVersion, _ := semver.Parse("4.8.10")
+				acked, pending, err := testValidator.(*validator).checkAdminAcks(currentVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(acked).Should(BeEmpty())
+				Expect(pending).Should(BeEmpty())
+			})
+		})
+		Context("When acknowledging admin gates as the upgrade starts", func() {
+			It("Acknowledges pending gates and records that the operator did", func() {
+				testValidator = &validator{client: mockKubeClient, config: ValidationConfig{AdminAcks: AdminAcksConfig{AutoAcknowledge: true}}}
+				gomock.InOrder(
+					mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, *gatesCm),
+					mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, *acksCm),
+					mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, *acksCm),
+					mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(ctx context.Context, cm *corev1.ConfigMap, opts ...client.UpdateOption) error {
+							Expect(cm.Data).Should(HaveKeyWithValue("ack-4.8-kube-1.22-api-removals-in-4.9", "true"))
+							Expect(cm.Annotations).Should(HaveKeyWithValue(autoAcknowledgedAnnotation, "ack-4.8-kube-1.22-api-removals-in-4.9"))
+							return nil
+						}),
+				)
+				acked, err := testValidator.AcknowledgeAdminGates(testClusterVersion, testUpgradeConfig.Spec.Desired.Version, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(acked).Should(Equal([]string{"ack-4.8-kube-1.22-api-removals-in-4.9 acknowledged by managed-upgrade-operator"}))
+			})
+			It("Attributes gates the operator acknowledged earlier to the operator", func() {
+				acksCm.Data["ack-4.8-kube-1.22-api-removals-in-4.9"] = "true"
+				acksCm.Annotations = map[string]string{autoAcknowledgedAnnotation: "ack-4.8-kube-1.22-api-removals-in-4.9"}
+				gomock.InOrder(
+					mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, *gatesCm),
+					mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, *acksCm),
+				)
+				currentVersion, _ := semver.Parse("4.8.10")
+				acked, _, err := testValidator.(*validator).checkAdminAcks(currentVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(acked).Should(Equal([]string{"ack-4.8-kube-1.22-api-removals-in-4.9 acknowledged by managed-upgrade-operator"}))
+			})
+			It("Leaves admin acknowledgements untouched for a z-stream upgrade", func() {
+				testValidator = &validator{client: mockKubeClient, config: ValidationConfig{AdminAcks: AdminAcksConfig{AutoAcknowledge: true}}}
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				mockKubeClient.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+				acked, err := testValidator.AcknowledgeAdminGates(testClusterVersion, "4.8.11", testLogger)
+				Expect(err).Should(BeNil())
+				Expect(acked).Should(BeNil())
+			})
+			It("Does nothing unless configured to acknowledge gates", func() {
+				acked, err := testValidator.AcknowledgeAdminGates(testClusterVersion, testUpgradeConfig.Spec.Desired.Version, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(acked).Should(BeNil())
+			})
+		})
+		Context("When the cluster does not declare admin gates", func() {
+			It("Reports no acknowledgements", func() {
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, adminGatesConfigMap))
+				currentVersion, _ := semver.Parse("4.8.10")
+				gates, err := getAdminGates(mockKubeClient, currentVersion)
+				Expect(err).Should(BeNil())
+				Expect(gates).Should(BeEmpty())
+			})
+		})
+	})
+	Context("Validating against the configured update graph source", func() {
+		const graphJSON = `{
+			"nodes": [
+				{"version": "4.8.10", "payload": "quay.io/openshift-release-dev/ocp-release@sha256:aaa", "metadata": {"io.openshift.upgrades.graph.release.channels": "stable-4.8"}},
+				{"version": "4.8.11", "payload": "quay.io/openshift-release-dev/ocp-release@sha256:bbb", "metadata": {"io.openshift.upgrades.graph.release.channels": "candidate-4.8,stable-4.8"}},
+				{"version": "4.8.12", "payload": "quay.io/openshift-release-dev/ocp-release@sha256:ccc", "metadata": {"io.openshift.upgrades.graph.release.channels": "candidate-4.8"}}
+			],
+			"edges": [[0, 1], [0, 2]]
+		}`
+		var graphCfg GraphConfig
+
+		BeforeEach(func() {
+			testUpgradeConfig.Spec.Desired.Version = "4.8.11"
+			testUpgradeConfig.Spec.Desired.Channel = "stable-4.8"
+			testClusterVersion.Status.History[1].Version = "4.8.10"
+			graphCfg = GraphConfig{
+				Source:    GraphSourceConfigMap,
+				ConfigMap: GraphConfigMapConfig{Namespace: "openshift-update-service", Name: "graph-data"},
+			}
+		})
+
+		Context("When the update graph is held in a ConfigMap", func() {
+			It("Returns the updates available in the channel", func() {
+				mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Namespace: "openshift-update-service", Name: "graph-data"}, gomock.Any()).SetArg(2, corev1.ConfigMap{Data: map[string]string{"graph.json": graphJSON}})
+				currentVersion, _ := semver.Parse("4.8.10")
+				graph, err := getConfigMapGraph(mockKubeClient, graphCfg)
+				Expect(err).Should(BeNil())
+				updates, err := graph.getUpdates("stable-4.8", currentVersion)
+				Expect(err).Should(BeNil())
+				Expect(updates).Should(Equal([]configv1.Update{{Version: "4.8.11", Image: "quay.io/openshift-release-dev/ocp-release@sha256:bbb"}}))
+			})
+			It("Validation is true and records the release image when the desired version is in the channel", func() {
+				testValidator = &validator{client: mockKubeClient, config: ValidationConfig{Graph: graphCfg}}
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, corev1.ConfigMap{Data: map[string]string{"graph.json": graphJSON}})
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeTrue())
+				Expect(result.ReleaseImage).Should(Equal("quay.io/openshift-release-dev/ocp-release@sha256:bbb"))
+			})
+			It("Validation is false when the desired version is not in the channel", func() {
+				testValidator = &validator{client: mockKubeClient, config: ValidationConfig{Graph: graphCfg}}
+				testUpgradeConfig.Spec.Desired.Version = "4.8.12"
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, corev1.ConfigMap{Data: map[string]string{"graph.json": graphJSON}})
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeFalse())
+				Expect(result.Reason).Should(Equal(ReasonVersionNotInChannel))
+			})
+			It("Returns an error when the ConfigMap has no graph", func() {
+				mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, corev1.ConfigMap{})
+				_, err := getConfigMapGraph(mockKubeClient, graphCfg)
+				Expect(err).ShouldNot(BeNil())
+			})
+		})
+		Context("When release signatures are checked instead of the update graph", func() {
+			BeforeEach(func() {
+				testValidator = &validator{client: mockKubeClient, config: ValidationConfig{Graph: GraphConfig{
+					Source:        GraphSourceReleaseSignature,
+					ReleaseImages: map[string]string{"4.8.11": "quay.io/openshift-release-dev/ocp-release@sha256:bbb"},
+				}}}
+			})
+			It("Validation is true when the release image is signed", func() {
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, corev1.ConfigMapList{
+					Items: []corev1.ConfigMap{{BinaryData: map[string][]byte{"sha256-bbb-1": []byte("signature")}}},
+				})
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeTrue())
+				Expect(result.IsAvailableUpdate).Should(BeTrue())
+				Expect(result.ReleaseImage).Should(Equal("quay.io/openshift-release-dev/ocp-release@sha256:bbb"))
+			})
+			It("Validation is false when the release image is not signed", func() {
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, corev1.ConfigMapList{
+					Items: []corev1.ConfigMap{{BinaryData: map[string][]byte{"sha256-aaa-1": []byte("signature")}}},
+				})
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeFalse())
+			})
+			It("Validation is false when no release image is configured for the version", func() {
+				testUpgradeConfig.Spec.Desired.Version = "4.8.12"
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeFalse())
+			})
+		})
+		Context("When the graph source config is incomplete", func() {
+			It("Is reported as invalid", func() {
+				Expect((&GraphConfig{Source: GraphSourceUpdateService}).IsValid()).ShouldNot(BeNil())
+				Expect((&GraphConfig{Source: GraphSourceConfigMap}).IsValid()).ShouldNot(BeNil())
+				Expect((&GraphConfig{Source: "unknown"}).IsValid()).ShouldNot(BeNil())
+				Expect((&GraphConfig{}).IsValid()).Should(BeNil())
+			})
+		})
+	})
+	Context("Validating conditional updates", func() {
+		const graphJSON = `{
+			"nodes": [
+				{"version": "4.8.10", "payload": "quay.io/openshift-release-dev/ocp-release@sha256:aaa"},
+				{"version": "4.8.11", "payload": "quay.io/openshift-release-dev/ocp-release@sha256:bbb"}
+			],
+			"edges": [],
+			"conditionalEdges": [
+				{
+					"edges": [{"from": "4.8.10", "to": "4.8.11"}],
+					"risks": [
+						{"name": "AlwaysRisk", "url": "https://example.com/always", "message": "Applies everywhere", "matchingRules": [{"type": "Always"}]},
+						{"name": "PromQLRisk", "url": "https://example.com/promql", "message": "Applies to some clusters", "matchingRules": [{"type": "PromQL", "promql": {"promql": "cluster_infrastructure_provider{type=\"AWS\"}"}}]}
+					]
+				}
+			]
+		}`
+		var mockMetricsClient *mockMetrics.MockMetrics
+
+		BeforeEach(func() {
+			mockMetricsClient = mockMetrics.NewMockMetrics(mockCtrl)
+			testUpgradeConfig.Spec.Desired.Version = "4.8.11"
+			testClusterVersion.Status.History[1].Version = "4.8.10"
+			testValidator = &validator{client: mockKubeClient, metrics: mockMetricsClient, config: ValidationConfig{Graph: GraphConfig{
+				Source:    GraphSourceConfigMap,
+				ConfigMap: GraphConfigMapConfig{Namespace: "openshift-update-service", Name: "graph-data"},
+			}}}
+			mockKubeClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, corev1.ConfigMap{Data: map[string]string{"graph.json": graphJSON}})
+		})
+
+		Context("When risks of the conditional update apply to the cluster", func() {
+			It("Validation is false and the risks are reported", func() {
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(&metrics.AlertResponse{Data: metrics.AlertData{Result: []metrics.AlertResult{{Value: []interface{}{float64(0), "1"}}}}}, nil)
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeFalse())
+				Expect(result.ConditionalUpdateRisks).Should(Equal([]string{"AlwaysRisk (https://example.com/always)", "PromQLRisk (https://example.com/promql)"}))
+			})
+			It("Validation is true when the risks are accepted", func() {
+				testUpgradeConfig.Spec.Desired.AcceptedRisks = []string{"AlwaysRisk"}
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(&metrics.AlertResponse{Data: metrics.AlertData{Result: []metrics.AlertResult{{Value: []interface{}{float64(0), "0"}}}}}, nil)
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeTrue())
+				Expect(result.ConditionalUpdateRisks).Should(Equal([]string{"AlwaysRisk (https://example.com/always)"}))
+			})
+		})
+		Context("When a risk's PromQL rule cannot be evaluated", func() {
+			It("The risk is assumed to apply", func() {
+				testUpgradeConfig.Spec.Desired.AcceptedRisks = []string{"AlwaysRisk"}
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(nil, fmt.Errorf("fake error"))
+				result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+				Expect(err).Should(BeNil())
+				Expect(result.IsValid).Should(BeFalse())
+				Expect(result.ConditionalUpdateRisks).Should(ContainElement("PromQLRisk (https://example.com/promql)"))
+			})
+		})
+	})
+	Context("Retrieving the update graph from a Cincinnati upstream", func() {
+		BeforeEach(func() {
+			httpmock.Activate()
+			testClusterVersion.Spec.ClusterID = "f8b4f0c5-7e8c-4a25-9b57-5b3f9c4a7e21"
+		})
+		AfterEach(func() {
+			httpmock.DeactivateAndReset()
+		})
+		It("Returns the updates served for the current version and channel", func() {
+			httpmock.RegisterResponder("GET", "https://update-service.example.com/graph",
+				func(req *http.Request) (*http.Response, error) {
+					Expect(req.URL.Query().Get("channel")).Should(Equal("stable-4.8"))
+					Expect(req.URL.Query().Get("version")).Should(Equal("4.8.10"))
+					return httpmock.NewStringResponse(200, `{"nodes":[{"version":"4.8.10","payload":"a"},{"version":"4.8.11","payload":"b"}],"edges":[[0,1]]}`), nil
+				})
+			currentVersion, _ := semver.Parse("4.8.10")
+			upstreamURI, _ := url.Parse("https://update-service.example.com/graph")
+			updates, err := getCincinnatiUpdates(uuid.MustParse(string(testClusterVersion.Spec.ClusterID)), upstreamURI, "stable-4.8", currentVersion)
+			Expect(err).Should(BeNil())
+			Expect(updates).Should(Equal([]configv1.Update{{Version: "4.8.11", Image: "b"}}))
+		})
+		It("Returns an error when the upstream is unavailable", func() {
+			httpmock.RegisterResponder("GET", "https://update-service.example.com/graph", httpmock.NewStringResponder(503, ""))
+			currentVersion, _ := semver.Parse("4.8.10")
+			upstreamURI, _ := url.Parse("https://update-service.example.com/graph")
+			_, err := getCincinnatiUpdates(uuid.MustParse(string(testClusterVersion.Spec.ClusterID)), upstreamURI, "stable-4.8", currentVersion)
+			Expect(err).ShouldNot(BeNil())
+		})
+	})
+	Context("Validating conditional updates offered by the CVO", func() {
+		var mockMetricsClient *mockMetrics.MockMetrics
+
+		BeforeEach(func() {
+			httpmock.Activate()
+			mockMetricsClient = mockMetrics.NewMockMetrics(mockCtrl)
+			testValidator = &validator{client: mockKubeClient, metrics: mockMetricsClient}
+			testUpgradeConfig.Spec.Desired.Version = "4.8.11"
+			testClusterVersion.Status.History[1].Version = "4.8.10"
+			testClusterVersion.Spec.ClusterID = "f8b4f0c5-7e8c-4a25-9b57-5b3f9c4a7e21"
+			testClusterVersion.Spec.Upstream = "https://update-service.example.com/graph"
+			httpmock.RegisterResponder("GET", "https://update-service.example.com/graph",
+				httpmock.NewStringResponder(200, `{"nodes":[{"version":"4.8.10","payload":"a"}],"edges":[]}`))
+			mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: "version"}, gomock.Any()).DoAndReturn(
+				func(ctx context.Context, key client.ObjectKey, obj *unstructured.Unstructured) error {
+					obj.Object["status"] = map[string]interface{}{
+						"conditionalUpdates": []interface{}{
+							map[string]interface{}{
+								"release": map[string]interface{}{"version": "4.8.11", "image": "b"},
+								"risks": []interface{}{
+									map[string]interface{}{"name": "AlwaysRisk", "url": "https://example.com/always", "message": "Applies everywhere", "matchingRules": []interface{}{map[string]interface{}{"type": "Always"}}},
+								},
+							},
+						},
+					}
+					return nil
+				})
+		})
+		AfterEach(func() {
+			httpmock.DeactivateAndReset()
+		})
+
+		It("Validation is false when the risks of the conditional update have not been accepted", func() {
+			result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+			Expect(err).Should(BeNil())
+			Expect(result.IsValid).Should(BeFalse())
+			Expect(result.Reason).Should(Equal(ReasonConditionalUpdateRisks))
+		})
+		It("Validation is true when the risks of the conditional update are accepted", func() {
+			testUpgradeConfig.Spec.Desired.AcceptedRisks = []string{"AlwaysRisk"}
+			result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+			Expect(err).Should(BeNil())
+			Expect(result.IsValid).Should(BeTrue())
+			Expect(result.ConditionalUpdateRisks).Should(Equal([]string{"AlwaysRisk (https://example.com/always)"}))
+			Expect(result.ReleaseImage).Should(BeEmpty())
+		})
+	})
+	Context("Validating against an OpenShift Update Service", func() {
+		BeforeEach(func() {
+			httpmock.Activate()
+			testValidator = &validator{client: mockKubeClient, config: ValidationConfig{Graph: GraphConfig{
+				Source: GraphSourceUpdateService,
+				URL:    "https://local-update-service.example.com/graph",
+			}}}
+			testUpgradeConfig.Spec.Desired.Version = "4.8.11"
+			testClusterVersion.Status.History[1].Version = "4.8.10"
+			testClusterVersion.Spec.ClusterID = "f8b4f0c5-7e8c-4a25-9b57-5b3f9c4a7e21"
+			httpmock.RegisterResponder("GET", "https://local-update-service.example.com/graph",
+				httpmock.NewStringResponder(200, `{"nodes":[{"version":"4.8.10","payload":"a"},{"version":"4.8.11","payload":"b"}],"edges":[[0,1]]}`))
+			mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: "version"}, gomock.Any())
+		})
+		AfterEach(func() {
+			httpmock.DeactivateAndReset()
+		})
+
+		It("Records the release image when the update service is not the CVO's upstream", func() {
+			testClusterVersion.Spec.Upstream = "https://api.openshift.com/api/upgrades_info/v1/graph"
+			result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+			Expect(err).Should(BeNil())
+			Expect(result.IsValid).Should(BeTrue())
+			Expect(result.ReleaseImage).Should(Equal("b"))
+		})
+		It("Does not record the release image when the update service is the CVO's upstream", func() {
+			testClusterVersion.Spec.Upstream = "https://local-update-service.example.com/graph"
+			result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+			Expect(err).Should(BeNil())
+			Expect(result.IsValid).Should(BeTrue())
+			Expect(result.ReleaseImage).Should(BeEmpty())
+		})
+	})
+	Context("Querying the update graph of a Cincinnati upstream", func() {
+		It("Validation is false when the cluster ID cannot be parsed", func() {
+			testUpgradeConfig.Spec.Desired.Version = "4.8.11"
+			testClusterVersion.Status.History[1].Version = "4.8.10"
+			testClusterVersion.Spec.ClusterID = "not-a-uuid"
+			result, err := testValidator.IsValidUpgradeConfig(testUpgradeConfig, testClusterVersion, testLogger)
+			Expect(err).Should(BeNil())
+			Expect(result.IsValid).Should(BeFalse())
+			Expect(result.Reason).Should(Equal(ReasonGraphQueryInvalid))
+		})
+	})
 	Context("Validating ClusterVersion Upstream configuration", func() {
 		Context("When ClusterVersion Upstream is defined explicitly", func() {
 			It("Explicit value is returned", func() {
@@ -0,0 +1,146 @@
+// Package workloads provides functions to assess the availability of customer workloads across an upgrade.
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+)
+
+const (
+	// DeploymentKind is the kind recorded for Deployment workloads
+	DeploymentKind = "Deployment"
+	// StatefulSetKind is the kind recorded for StatefulSet workloads
+	StatefulSetKind = "StatefulSet"
+
+	// MaxSnapshotSize bounds the workloads recorded in an availability snapshot, so the UpgradeConfig holding it
+	// stays within the API server's object size limit however many workloads the cluster runs
+	MaxSnapshotSize = 500
+)
+
+// platformNamespaces matches the namespaces which are owned by the platform rather than the customer
+var platformNamespaces = regexp.MustCompile(`^openshift.*|^kube-.*|^default$`)
+
+// GetAvailability returns the ready and desired replicas of the Deployments and StatefulSets in scope.
+// If no namespaces are specified, all non-platform namespaces are in scope.
+func GetAvailability(c client.Client, namespaces []string) ([]upgradev1alpha1.WorkloadAvailability, error) {
+	inScope := namespaceFilter(namespaces)
+	availability := []upgradev1alpha1.WorkloadAvailability{}
+
+	deployments := &appsv1.DeploymentList{}
+	err := c.List(context.TODO(), deployments)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range deployments.Items {
+		if !inScope(d.Namespace) {
+			continue
+		}
+		availability = append(availability, upgradev1alpha1.WorkloadAvailability{
+			Kind:          DeploymentKind,
+			Namespace:     d.Namespace,
+			Name:          d.Name,
+			ReadyReplicas: d.Status.ReadyReplicas,
+			Replicas:      desiredReplicas(d.Spec.Replicas),
+		})
+	}
+
+	statefulSets := &appsv1.StatefulSetList{}
+	err = c.List(context.TODO(), statefulSets)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range statefulSets.Items {
+		if !inScope(s.Namespace) {
+			continue
+		}
+		availability = append(availability, upgradev1alpha1.WorkloadAvailability{
+			Kind:          StatefulSetKind,
+			Namespace:     s.Namespace,
+			Name:          s.Name,
+			ReadyReplicas: s.Status.ReadyReplicas,
+			Replicas:      desiredReplicas(s.Spec.Replicas),
+		})
+	}
+
+	return availability, nil
+}
+
+// GetSnapshot returns the workloads to record from an availability snapshot, and whether any were left out.
+// Workloads with no ready replicas can't lose availability, so are not recorded, and at most MaxSnapshotSize
+// workloads are recorded.
+func GetSnapshot(availability []upgradev1alpha1.WorkloadAvailability) ([]upgradev1alpha1.WorkloadAvailability, bool) {
+	snapshot := []upgradev1alpha1.WorkloadAvailability{}
+	for _, w := range availability {
+		if w.ReadyReplicas == 0 {
+			continue
+		}
+		if len(snapshot) == MaxSnapshotSize {
+			return snapshot, true
+		}
+		snapshot = append(snapshot, w)
+	}
+	return snapshot, false
+}
+
+// FindDegraded compares two availability snapshots and returns the workloads whose
+// ready replicas dropped. Workloads which no longer exist are not reported, and workloads
+// which have since been scaled down are only expected to have as many ready replicas as they
+// now desire.
+func FindDegraded(before, after []upgradev1alpha1.WorkloadAvailability) []string {
+	current := make(map[string]upgradev1alpha1.WorkloadAvailability)
+	for _, w := range after {
+		current[workloadName(w)] = w
+	}
+
+	degraded := []string{}
+	for _, w := range before {
+		c, ok := current[workloadName(w)]
+		if !ok {
+			continue
+		}
+		expected := w.ReadyReplicas
+		if c.Replicas < expected {
+			expected = c.Replicas
+		}
+		if c.ReadyReplicas >= expected {
+			continue
+		}
+		degraded = append(degraded, fmt.Sprintf("%s (ready %d/%d)", workloadName(w), c.ReadyReplicas, expected))
+	}
+
+	return degraded
+}
+
+// desiredReplicas returns the replicas desired by a workload's spec, which default to 1
+func desiredReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+func workloadName(w upgradev1alpha1.WorkloadAvailability) string {
+	return fmt.Sprintf("%s %s/%s", w.Kind, w.Namespace, w.Name)
+}
+
+func namespaceFilter(namespaces []string) func(string) bool {
+	if len(namespaces) == 0 {
+		return func(ns string) bool {
+			return !platformNamespaces.MatchString(ns)
+		}
+	}
+
+	selected := make(map[string]bool)
+	for _, ns := range namespaces {
+		selected[ns] = true
+	}
+	return func(ns string) bool {
+		return selected[ns]
+	}
+}
@@ -0,0 +1,13 @@
+package workloads
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestWorkloads(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Workloads Suite")
+}
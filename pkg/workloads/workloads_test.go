@@ -0,0 +1,144 @@
+package workloads
+
+import (
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/pkg/apis/upgrade/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+)
+
+var _ = Describe("Workload availability", func() {
+	var (
+		mockCtrl       *gomock.Controller
+		mockKubeClient *mocks.MockClient
+		deployments    *appsv1.DeploymentList
+		statefulSets   *appsv1.StatefulSetList
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		replicas := int32(3)
+		deployments = &appsv1.DeploymentList{
+			Items: []appsv1.Deployment{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "customer", Name: "web"},
+					Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+					Status:     appsv1.DeploymentStatus{ReadyReplicas: 3},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "openshift-monitoring", Name: "grafana"},
+					Status:     appsv1.DeploymentStatus{ReadyReplicas: 1},
+				},
+			},
+		}
+		statefulSets = &appsv1.StatefulSetList{
+			Items: []appsv1.StatefulSet{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "database", Name: "postgres"},
+					Status:     appsv1.StatefulSetStatus{ReadyReplicas: 2},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When recording workload availability", func() {
+		It("records workloads in all non-platform namespaces by default", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *deployments).Return(nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *statefulSets).Return(nil),
+			)
+			result, err := GetAvailability(mockKubeClient, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(ConsistOf(
+				upgradev1alpha1.WorkloadAvailability{Kind: DeploymentKind, Namespace: "customer", Name: "web", ReadyReplicas: 3, Replicas: 3},
+				upgradev1alpha1.WorkloadAvailability{Kind: StatefulSetKind, Namespace: "database", Name: "postgres", ReadyReplicas: 2, Replicas: 1},
+			))
+		})
+		It("records only workloads in the selected namespaces", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *deployments).Return(nil),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *statefulSets).Return(nil),
+			)
+			result, err := GetAvailability(mockKubeClient, []string{"database"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(ConsistOf(
+				upgradev1alpha1.WorkloadAvailability{Kind: StatefulSetKind, Namespace: "database", Name: "postgres", ReadyReplicas: 2, Replicas: 1},
+			))
+		})
+		It("reports an error listing workloads", func() {
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error"))
+			result, err := GetAvailability(mockKubeClient, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeNil())
+		})
+	})
+
+	Context("When snapshotting workload availability", func() {
+		It("does not record workloads without ready replicas", func() {
+			availability := []upgradev1alpha1.WorkloadAvailability{
+				{Kind: DeploymentKind, Namespace: "customer", Name: "web", ReadyReplicas: 3},
+				{Kind: DeploymentKind, Namespace: "customer", Name: "batch", ReadyReplicas: 0},
+			}
+			snapshot, truncated := GetSnapshot(availability)
+			Expect(truncated).To(BeFalse())
+			Expect(snapshot).To(ConsistOf(availability[0]))
+		})
+		It("records at most the maximum number of workloads", func() {
+			availability := []upgradev1alpha1.WorkloadAvailability{}
+			for i := 0; i <= MaxSnapshotSize; i++ {
+				availability = append(availability, upgradev1alpha1.WorkloadAvailability{Kind: DeploymentKind, Namespace: "customer", Name: fmt.Sprintf("web-%d", i), ReadyReplicas: 1})
+			}
+			snapshot, truncated := GetSnapshot(availability)
+			Expect(truncated).To(BeTrue())
+			Expect(snapshot).To(HaveLen(MaxSnapshotSize))
+		})
+	})
+
+	Context("When comparing workload availability", func() {
+		var before []upgradev1alpha1.WorkloadAvailability
+		BeforeEach(func() {
+			before = []upgradev1alpha1.WorkloadAvailability{
+				{Kind: DeploymentKind, Namespace: "customer", Name: "web", ReadyReplicas: 3},
+				{Kind: StatefulSetKind, Namespace: "database", Name: "postgres", ReadyReplicas: 2},
+			}
+		})
+		It("reports workloads whose ready replicas dropped", func() {
+			after := []upgradev1alpha1.WorkloadAvailability{
+				{Kind: DeploymentKind, Namespace: "customer", Name: "web", ReadyReplicas: 1, Replicas: 3},
+				{Kind: StatefulSetKind, Namespace: "database", Name: "postgres", ReadyReplicas: 2, Replicas: 2},
+			}
+			Expect(FindDegraded(before, after)).To(ConsistOf("Deployment customer/web (ready 1/3)"))
+		})
+		It("does not report workloads which no longer exist", func() {
+			after := []upgradev1alpha1.WorkloadAvailability{
+				{Kind: DeploymentKind, Namespace: "customer", Name: "web", ReadyReplicas: 4, Replicas: 4},
+			}
+			Expect(FindDegraded(before, after)).To(BeEmpty())
+		})
+		It("does not report workloads which have been scaled down", func() {
+			after := []upgradev1alpha1.WorkloadAvailability{
+				{Kind: DeploymentKind, Namespace: "customer", Name: "web", ReadyReplicas: 1, Replicas: 1},
+				{Kind: StatefulSetKind, Namespace: "database", Name: "postgres", ReadyReplicas: 0, Replicas: 0},
+			}
+			Expect(FindDegraded(before, after)).To(BeEmpty())
+		})
+		It("reports workloads which have been scaled down with fewer replicas ready than they desire", func() {
+			after := []upgradev1alpha1.WorkloadAvailability{
+				{Kind: DeploymentKind, Namespace: "customer", Name: "web", ReadyReplicas: 1, Replicas: 2},
+				{Kind: StatefulSetKind, Namespace: "database", Name: "postgres", ReadyReplicas: 2, Replicas: 2},
+			}
+			Expect(FindDegraded(before, after)).To(ConsistOf("Deployment customer/web (ready 1/2)"))
+		})
+	})
+})